@@ -16,6 +16,7 @@ func InitializeApp() (*App, error) {
 		NewApp,
 		logger.NewSugaredLogger,
 		api.NewGrpcHandlerImpl,
+		service.NewKubeCheckerRegistry,
 		service.NewClusterUpgradeReadServiceImpl,
 		wire.Bind(new(service.ClusterUpgradeReadService), new(*service.ClusterUpgradeReadServiceImpl)),
 		k8s.GetRuntimeConfig,
@@ -20,10 +20,21 @@ func ConvertSummaryValidationResultToGrpcObj(req []pkg.SummaryValidationResult)
 			Deprecated:             item.Deprecated,
 			LatestAPIVersion:       item.LatestAPIVersion,
 			IsVersionSupported:     int32(item.IsVersionSupported),
+			SchemaNotFound:         item.SchemaNotFound,
 			ErrorsForOriginal:      ConvertSummarySchemaErrorToGrpcObj(item.ErrorsForOriginal),
 			ErrorsForLatest:        ConvertSummarySchemaErrorToGrpcObj(item.ErrorsForLatest),
 			DeprecationForOriginal: ConvertSummarySchemaErrorToGrpcObj(item.DeprecationForOriginal),
 			DeprecationForLatest:   ConvertSummarySchemaErrorToGrpcObj(item.DeprecationForLatest),
+			FieldRemovals:          ConvertSummarySchemaErrorToGrpcObj(item.FieldRemovals),
+			UnknownFields:          ConvertSummarySchemaErrorToGrpcObj(item.UnknownFields),
+			RuleViolations:         ConvertSummarySchemaErrorToGrpcObj(item.RuleViolations),
+			BehaviourChanges:       ConvertSummarySchemaErrorToGrpcObj(item.BehaviourChanges),
+			DocumentIndex:          int32(item.DocumentIndex),
+			Line:                   int32(item.Line),
+			Column:                 int32(item.Column),
+			ListItemPath:           item.ListItemPath,
+			DeprecatedInRelease:    item.DeprecatedInRelease,
+			RemovedInRelease:       item.RemovedInRelease,
 		}
 		resp = append(resp, svr)
 	}
@@ -38,6 +49,11 @@ func ConvertSummarySchemaErrorToGrpcObj(req []*pkg.SummarySchemaError) []*grpc.S
 				Path:        item.Path,
 				SchemaField: item.SchemaField,
 				Reason:      item.Reason,
+				RuleID:      item.RuleID,
+				Severity:    item.Severity,
+				Remediation: item.Remediation,
+				Line:        int32(item.Line),
+				Column:      int32(item.Column),
 			}
 			resp = append(resp, sse)
 		}
@@ -6,6 +6,9 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+
+	// registers openapi specs bundled into the binary in offline builds
+	_ "github.com/devtron-labs/silver-surfer/pkg/schemas"
 )
 
 func main() {
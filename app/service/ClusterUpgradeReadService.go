@@ -15,24 +15,30 @@ import (
 )
 
 type ClusterUpgradeReadService interface {
-	GetClusterUpgradeSummaryValidationResult(targetK8sVersion string, clusterConfig *grpc.ClusterConfig) ([]pkg.SummaryValidationResult, error)
+	GetClusterUpgradeSummaryValidationResult(targetK8sVersion string, clusterConfig *grpc.ClusterConfig, apiLifecycles bool) ([]pkg.SummaryValidationResult, error)
 }
 
 type ClusterUpgradeReadServiceImpl struct {
-	logger  *zap.SugaredLogger
-	k8sUtil k8s2.K8sService
+	logger              *zap.SugaredLogger
+	k8sUtil             k8s2.K8sService
+	kubeCheckerRegistry *pkg.KubeCheckerRegistry
 }
 
-func NewClusterUpgradeReadServiceImpl(logger *zap.SugaredLogger, k8sUtil k8s2.K8sService) *ClusterUpgradeReadServiceImpl {
+func NewClusterUpgradeReadServiceImpl(logger *zap.SugaredLogger, k8sUtil k8s2.K8sService, kubeCheckerRegistry *pkg.KubeCheckerRegistry) *ClusterUpgradeReadServiceImpl {
 	return &ClusterUpgradeReadServiceImpl{
-		logger:  logger,
-		k8sUtil: k8sUtil,
+		logger:              logger,
+		k8sUtil:             k8sUtil,
+		kubeCheckerRegistry: kubeCheckerRegistry,
 	}
 }
 
-func (impl *ClusterUpgradeReadServiceImpl) GetClusterUpgradeSummaryValidationResult(targetK8sVersion string, clusterConfig *grpc.ClusterConfig) ([]pkg.SummaryValidationResult, error) {
+func (impl *ClusterUpgradeReadServiceImpl) GetClusterUpgradeSummaryValidationResult(targetK8sVersion string, clusterConfig *grpc.ClusterConfig, apiLifecycles bool) ([]pkg.SummaryValidationResult, error) {
 	var restConfig *rest.Config
 	var err error
+	if _, err = pkg.ResolveReleaseVersion(targetK8sVersion, nil); err != nil {
+		impl.logger.Errorw("invalid target kubernetes version", "targetK8sVersion", targetK8sVersion, "err", err)
+		return nil, err
+	}
 	localClusterConfig := adaptors.ConvertGrpcObjToClusterConfig(clusterConfig)
 	if len(localClusterConfig.ClusterName) > 0 {
 		impl.logger.Infow("fetching restConfig via GetRestConfigByCluster", "clusterName", localClusterConfig.ClusterName)
@@ -43,7 +49,7 @@ func (impl *ClusterUpgradeReadServiceImpl) GetClusterUpgradeSummaryValidationRes
 		}
 	}
 	cluster := pkg.NewClusterFromEnvOrConfig(restConfig)
-	results, err := kubedd.ValidateCluster(cluster, &pkg.Config{TargetKubernetesVersion: targetK8sVersion})
+	results, err := kubedd.ValidateClusterWithRegistry(impl.kubeCheckerRegistry, cluster, &pkg.Config{TargetKubernetesVersion: targetK8sVersion, ApiLifecycles: apiLifecycles})
 	if err != nil {
 		impl.logger.Errorw("error in ValidateCluster", "err", err)
 		if errors.Is(err, errors2.ErrOpenApiSpecNotFound) {
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/silver-surfer/pkg"
+	"go.uber.org/zap"
+	"time"
+)
+
+type KubeCheckerRegistryConfig struct {
+	MemoryBudget int64 `env:"SCHEMA_REGISTRY_MEMORY_BUDGET" envDefault:"512"` // In mb of openapi 3 documents specs are parsed from, specs beyond it are evicted
+	// ClusterSpecTTL is the duration for which specs served by the api server of a cluster are shared between requests
+	ClusterSpecTTL time.Duration `env:"SCHEMA_REGISTRY_CLUSTER_SPEC_TTL" envDefault:"10m"`
+}
+
+// NewKubeCheckerRegistry returns registry sharing parsed openapi specs between concurrent requests
+func NewKubeCheckerRegistry(logger *zap.SugaredLogger) (*pkg.KubeCheckerRegistry, error) {
+	cfg := &KubeCheckerRegistryConfig{}
+	if err := env.Parse(cfg); err != nil {
+		logger.Errorw("error in parsing kube checker registry config", "err", err)
+		return nil, err
+	}
+	registry := pkg.NewKubeCheckerRegistry(cfg.MemoryBudget * 1024 * 1024)
+	registry.SetSourceSpecTTL(cfg.ClusterSpecTTL)
+	return registry, nil
+}
@@ -22,7 +22,11 @@ func InitializeApp() (*App, error) {
 		return nil, err
 	}
 	k8sServiceImpl := k8s.NewK8sUtil(sugaredLogger, runtimeConfig)
-	clusterUpgradeReadServiceImpl := service.NewClusterUpgradeReadServiceImpl(sugaredLogger, k8sServiceImpl)
+	kubeCheckerRegistry, err := service.NewKubeCheckerRegistry(sugaredLogger)
+	if err != nil {
+		return nil, err
+	}
+	clusterUpgradeReadServiceImpl := service.NewClusterUpgradeReadServiceImpl(sugaredLogger, k8sServiceImpl, kubeCheckerRegistry)
 	grpcHandlerImpl := api.NewGrpcHandlerImpl(sugaredLogger, clusterUpgradeReadServiceImpl)
 	app := NewApp(sugaredLogger, grpcHandlerImpl)
 	return app, nil
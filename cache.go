@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/devtron-labs/silver-surfer/pkg"
+	log2 "github.com/devtron-labs/silver-surfer/pkg/log"
+	"github.com/mgutz/ansi"
+	"github.com/spf13/cobra"
+	"github.com/tomlazar/table"
+	"os"
+	"time"
+)
+
+var (
+	pruneAll bool
+
+	cacheCmd = &cobra.Command{
+		Use:   "cache",
+		Short: "Manage openapi specs cached on disk",
+	}
+
+	cacheListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List cached openapi specs",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			schemaCache, err := getSchemaCache()
+			if err != nil {
+				log2.Error(err)
+				os.Exit(1)
+			}
+			entries, err := schemaCache.List()
+			if err != nil {
+				log2.Error(err)
+				os.Exit(1)
+			}
+			if len(entries) == 0 {
+				fmt.Printf("no openapi specs cached in %s\n", schemaCache.Dir())
+				return
+			}
+			t := table.Table{Headers: []string{"Release Version", "Fetched At", "Status", "Size", "Digest", "Url"}}
+			c := table.DefaultConfig()
+			c.TitleColorCode = ansi.ColorCode("cyan+bu")
+			c.AltColorCodes = []string{ansi.LightWhite, ansi.ColorCode("white+h:238")}
+			c.ShowIndex = false
+			c.Color = !noColor
+			for _, entry := range entries {
+				status := "fresh"
+				if entry.IsExpired(schemaCache.TTL()) {
+					status = "expired"
+				}
+				digest := entry.Digest
+				if len(digest) > 12 {
+					digest = digest[:12]
+				}
+				t.Rows = append(t.Rows, []string{entry.ReleaseVersion, entry.FetchedAt.Format(time.RFC3339), status,
+					fmt.Sprintf("%.1f MiB", float64(entry.Size)/(1024*1024)), digest, entry.Url})
+			}
+			t.WriteTable(os.Stdout, c)
+		},
+	}
+
+	cachePruneCmd = &cobra.Command{
+		Use:   "prune",
+		Short: "Remove cached openapi specs older than cache-ttl, or all of them with --all",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			schemaCache, err := getSchemaCache()
+			if err != nil {
+				log2.Error(err)
+				os.Exit(1)
+			}
+			olderThan := schemaCache.TTL()
+			if pruneAll {
+				olderThan = 0
+			}
+			removed, err := schemaCache.Prune(olderThan)
+			if err != nil {
+				log2.Error(err)
+				os.Exit(1)
+			}
+			for _, entry := range removed {
+				fmt.Printf("removed %s (%s)\n", entry.ReleaseVersion, entry.Url)
+			}
+			fmt.Printf("pruned %d cached openapi spec(s) from %s\n", len(removed), schemaCache.Dir())
+		},
+	}
+)
+
+func getSchemaCache() (*pkg.SchemaCache, error) {
+	if len(config.CacheDir) == 0 {
+		return nil, errors.New("cache directory is not set, use --cache-dir or " + pkg.CacheDirEnv)
+	}
+	return pkg.NewSchemaCache(config.CacheDir, config.CacheTTL, false), nil
+}
+
+func init() {
+	cachePruneCmd.Flags().BoolVar(&pruneAll, "all", false, "Remove every cached openapi spec")
+	cacheCmd.AddCommand(cacheListCmd, cachePruneCmd)
+	RootCmd.AddCommand(cacheCmd)
+}
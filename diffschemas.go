@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"errors"
+	"github.com/devtron-labs/silver-surfer/kubedd"
+	"github.com/devtron-labs/silver-surfer/pkg"
+	log2 "github.com/devtron-labs/silver-surfer/pkg/log"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var (
+	diffFrom = ""
+	diffTo   = ""
+
+	diffSchemasCmd = &cobra.Command{
+		Use:   "diff-schemas",
+		Short: "List differences between openapi specs of two kubernetes versions",
+		Long: `List differences between openapi specs of two kubernetes versions, i.e; kinds removed and added and for
+kinds served in both, fields removed and added, fields which became required and changes of types, enums, defaults
+and deprecation of fields. Use it as a checklist of changes to review before an upgrade.`,
+		Example: "  kubedd diff-schemas --from 1.25 --to 1.29\n  kubedd diff-schemas --from 1.25 --to latest -o json",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(diffFrom) == 0 || len(diffTo) == 0 {
+				log2.Error(errors.New("kubernetes versions to compare should be passed in --from and --to"))
+				os.Exit(1)
+			}
+			diff, err := kubedd.DiffSchemas(diffFrom, diffTo, config)
+			if err != nil {
+				log2.Error(err)
+				os.Exit(1)
+			}
+			if err := pkg.PutSchemaDiff(diff, config.OutputFormat, noColor); err != nil {
+				log2.Error(err)
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func init() {
+	diffSchemasCmd.Flags().StringVarP(&diffFrom, "from", "", "", "Version of Kubernetes to compare from eg 1.25")
+	diffSchemasCmd.Flags().StringVarP(&diffTo, "to", "", "", "Version of Kubernetes to compare to eg 1.29, v1.29.3 or latest")
+	diffSchemasCmd.Flags().StringVarP(&config.SchemaLocation, "schema-location", "", "", "Location of openapi specs of kubernetes versions, either a url template in which %s is replaced by the version, base url of a kubernetes repository mirror, a file path template, a directory of specs or a schema bundle. Defaults to the upstream kubernetes repository")
+	diffSchemasCmd.Flags().StringVarP(&config.OutputFormat, "output", "o", "", "The format of the output. Options are: (stdOut | json)")
+	diffSchemasCmd.Flags().BoolVarP(&noColor, "no-color", "", false, "Display results without color")
+	RootCmd.AddCommand(diffSchemasCmd)
+}
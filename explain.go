@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/devtron-labs/silver-surfer/kubedd"
+	"github.com/devtron-labs/silver-surfer/pkg"
+	log2 "github.com/devtron-labs/silver-surfer/pkg/log"
+	"github.com/mgutz/ansi"
+	"github.com/spf13/cobra"
+	"github.com/tomlazar/table"
+	"os"
+)
+
+// defaultExplainVersions is the range of releases explained if neither a schema bundle nor bundled specs are present
+const defaultExplainVersions = "1.16.." + pkg.LatestReleaseVersion
+
+var (
+	explainVersions = ""
+
+	explainCmd = &cobra.Command{
+		Use:   "explain <kind|apiVersion/kind>",
+		Short: "Explain in which kubernetes releases apiVersions of a kind are introduced, deprecated and removed",
+		Long: `Explain in which kubernetes releases apiVersions of a kind are introduced, deprecated and removed along
+with the apiVersion to replace them with. Releases of the schema bundle passed as --schema-location, or of specs
+bundled in the binary, are compared unless a range of releases is passed in --versions.`,
+		Example: "  kubedd explain Ingress\n  kubedd explain batch/v1beta1/CronJob --versions 1.19..1.29 -o json",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			releases, err := getExplainReleases()
+			if err != nil {
+				log2.Error(err)
+				os.Exit(1)
+			}
+			timeline, err := kubedd.ApiLifecycleTimeline(releases, config)
+			if err != nil {
+				log2.Error(err)
+				os.Exit(1)
+			}
+			lifecycles := timeline.Explain(args[0])
+			if len(lifecycles) == 0 {
+				log2.Error(fmt.Errorf("%s is not served in any of kubernetes versions %v", args[0], releases))
+				os.Exit(1)
+			}
+			if config.OutputFormat == "json" {
+				b, err := json.MarshalIndent(lifecycles, "", "\t")
+				if err != nil {
+					log2.Error(err)
+					os.Exit(1)
+				}
+				fmt.Println(string(b))
+				return
+			}
+			t := table.Table{Headers: []string{"Kind", "API Version", "Introduced In", "Deprecated In", "Removed In", "Replace With API Version"}}
+			c := table.DefaultConfig()
+			c.TitleColorCode = ansi.ColorCode("cyan+bu")
+			c.AltColorCodes = []string{ansi.LightWhite, ansi.ColorCode("white+h:238")}
+			c.ShowIndex = false
+			c.Color = !noColor
+			for _, lifecycle := range lifecycles {
+				t.Rows = append(t.Rows, []string{lifecycle.Kind, lifecycle.APIVersion(), lifecycle.IntroducedInRelease,
+					lifecycle.DeprecatedInRelease, lifecycle.RemovedInRelease, lifecycle.ReplacementAPIVersion})
+			}
+			fmt.Printf("Kubernetes versions %s to %s\n", releases[0], releases[len(releases)-1])
+			t.WriteTable(os.Stdout, c)
+		},
+	}
+)
+
+// getExplainReleases returns releases passed in --versions, otherwise those of the schema bundle or bundled specs.
+// Directories of specs without index.json are not bundles, bundled or default versions are used for them.
+func getExplainReleases() ([]string, error) {
+	if len(explainVersions) > 0 {
+		return pkg.ParseUpgradePath(explainVersions)
+	}
+	if pkg.HasBundleIndex(config.SchemaLocation) {
+		bundle, err := pkg.OpenSchemaBundle(config.SchemaLocation)
+		if err != nil {
+			return nil, err
+		}
+		return bundle.ReleaseVersions(), nil
+	}
+	if versions := pkg.GetEmbeddedReleaseVersions(); len(versions) > 0 {
+		return versions, nil
+	}
+	return pkg.ParseUpgradePath(defaultExplainVersions)
+}
+
+func init() {
+	explainCmd.Flags().StringVarP(&explainVersions, "versions", "", "", fmt.Sprintf("Range of kubernetes versions to compare eg 1.22..1.29, defaults to versions of the schema bundle or bundled specs, otherwise %s", defaultExplainVersions))
+	explainCmd.Flags().StringVarP(&config.SchemaLocation, "schema-location", "", "", "Location of openapi specs of kubernetes versions, either a url template in which %s is replaced by the version, base url of a kubernetes repository mirror, a file path template, a directory of specs or a schema bundle. Defaults to the upstream kubernetes repository")
+	explainCmd.Flags().StringVarP(&config.OutputFormat, "output", "o", "", "The format of the output. Options are: (stdOut | json)")
+	explainCmd.Flags().BoolVarP(&noColor, "no-color", "", false, "Display results without color")
+	RootCmd.AddCommand(explainCmd)
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/devtron-labs/silver-surfer/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExplainReleases(t *testing.T) {
+	bundle := t.TempDir()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(bundle, "index.json"),
+		[]byte(`{"versions": {"1.29": {"file": "1.29.json.gz"}, "1.22": {"file": "1.22.json.gz"}}}`), 0644))
+	specs := t.TempDir()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(specs, "1.22.json"), []byte(`{"swagger": "2.0"}`), 0644))
+	defaultReleases, err := pkg.ParseUpgradePath(defaultExplainVersions)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		schemaLocation string
+		specs          pkg.EmbeddedSpecs
+		want           []string
+	}{
+		{name: "schema bundle", schemaLocation: bundle, want: []string{"1.22", "1.29"}},
+		{name: "directory of specs", schemaLocation: specs, want: defaultReleases},
+		{name: "directory of specs with bundled specs", schemaLocation: specs, specs: testEmbeddedSpecs{"1.24", "1.25"}, want: []string{"1.24", "1.25"}},
+		{name: "no schema location", want: defaultReleases},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg.RegisterEmbeddedSpecs(tt.specs)
+			defer pkg.RegisterEmbeddedSpecs(nil)
+			config.SchemaLocation = tt.schemaLocation
+			defer func() { config.SchemaLocation = "" }()
+			releases, err := getExplainReleases()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, releases)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/devtron-labs/silver-surfer/kubedd"
+	"github.com/devtron-labs/silver-surfer/pkg"
+	log2 "github.com/devtron-labs/silver-surfer/pkg/log"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var (
+	helmOptions = kubedd.HelmOptions{}
+
+	helmCmd = &cobra.Command{
+		Use:   "helm <chart-dir|chart.tgz>",
+		Short: "Validates manifests rendered from a helm chart against source and target kubernetes versions",
+		Long: `Renders the helm chart in-process, as helm template does, with capabilities of the source and then of the
+target kubernetes version, i.e; .Capabilities.KubeVersion and .Capabilities.APIVersions served in the version, and
+validates manifests of every template. Manifests rendered for the source version are those deployed today, they are
+validated for migration to the target version, manifests rendered for the target version are those deployed once the
+chart is upgraded, they are validated against the target version. Results are reported per template of the chart.`,
+		Example: "  kubedd helm ./my-chart -f values.yaml --set image.tag=1.2.3 --source-kubernetes-version 1.24 --target-kubernetes-version 1.29",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if forceColor {
+				color.NoColor = false
+			} else if noColor {
+				color.NoColor = true
+			}
+			if !processHelmChart(args[0]) {
+				os.Exit(1)
+			}
+		},
+	}
+)
+
+func processHelmChart(chartPath string) bool {
+	if len(config.UpgradePath) > 0 {
+		log2.Error(errors.New("--upgrade-path is not supported for helm charts, use --source-kubernetes-version and --target-kubernetes-version instead"))
+		return false
+	}
+	success := true
+	outputManager := pkg.GetOutputManager(config.OutputFormat, noColor)
+	templateResults, err := kubedd.ValidateHelmChart(chartPath, helmOptions, config)
+	if err != nil {
+		log2.Error(err)
+		success = false
+		if len(templateResults) == 0 {
+			return success
+		}
+	}
+	var aggResults []pkg.ValidationResult
+	for _, templateResult := range templateResults {
+		fmt.Println("")
+		if len(templateResult.ReleaseVersion) > 0 {
+			fmt.Printf("Results for template %s rendered for kubernetes %s\n", templateResult.Template, templateResult.ReleaseVersion)
+		} else {
+			fmt.Printf("Results for template %s\n", templateResult.Template)
+		}
+		fmt.Println("-------------------------------------------")
+		outputManager.PutBulk(templateResult.Results)
+		aggResults = append(aggResults, templateResult.Results...)
+	}
+	success = success && !hasErrors(aggResults)
+	if err := outputManager.Flush(); err != nil {
+		log2.Error(err)
+		success = false
+	}
+	return success
+}
+
+func init() {
+	pkg.AddKubeaddFlags(helmCmd, config)
+	helmCmd.Flags().StringArrayVarP(&helmOptions.ValueFiles, "values", "f", []string{}, "Values file of the chart, can be repeated, later files take precedence")
+	helmCmd.Flags().StringArrayVarP(&helmOptions.Values, "set", "", []string{}, "Value of the chart in key=value form, as helm --set, can be repeated")
+	helmCmd.Flags().StringArrayVarP(&helmOptions.StringValues, "set-string", "", []string{}, "String value of the chart in key=value form, as helm --set-string, can be repeated")
+	helmCmd.Flags().StringVarP(&helmOptions.ReleaseName, "release-name", "", "release-name", "Name of the release the chart is rendered for")
+	helmCmd.Flags().StringVarP(&helmOptions.Namespace, "namespace", "n", "default", "Namespace of the release the chart is rendered for")
+	helmCmd.Flags().BoolVarP(&forceColor, "force-color", "", false, "Force colored output even if stdout is not a TTY")
+	helmCmd.Flags().BoolVarP(&noColor, "no-color", "", false, "Display results without color")
+	RootCmd.AddCommand(helmCmd)
+}
@@ -0,0 +1,294 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package kubedd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/devtron-labs/silver-surfer/pkg"
+	multierror "github.com/hashicorp/go-multierror"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/strvals"
+	"sigs.k8s.io/yaml"
+)
+
+// HelmOptions are the release and values a chart is rendered with, as passed to helm template
+type HelmOptions struct {
+	ReleaseName string
+	Namespace   string
+	// ValueFiles are merged in order, later files taking precedence
+	ValueFiles []string
+	// Values and StringValues are key=value pairs of --set and --set-string, taking precedence over ValueFiles
+	Values       []string
+	StringValues []string
+}
+
+// HelmTemplateResult is the result of validation of manifests a template of a chart renders
+type HelmTemplateResult struct {
+	// Template is the path of the template in the chart, eg my-chart/templates/ingress.yaml
+	Template string
+	// ReleaseVersion is the kubernetes release the template is rendered for, it is empty if the template renders the
+	// same manifests for source and target releases
+	ReleaseVersion string
+	Results        []pkg.ValidationResult
+}
+
+// ValidateHelmChart renders the chart at chartPath, a directory or a packaged chart, for conf.SourceKubernetesVersion
+// and conf.TargetKubernetesVersion and validates manifests of every template. Manifests rendered for the source
+// release are deployed today, they are validated across releases to find what breaks after the upgrade, those
+// rendered for the target release are deployed once the chart is upgraded along with the cluster, they are validated
+// against the target release. Charts which switch apiVersions on capabilities are thus checked on both paths.
+func ValidateHelmChart(chartPath string, opts HelmOptions, conf *pkg.Config) ([]HelmTemplateResult, error) {
+	return ValidateHelmChartWithRegistry(pkg.NewKubeCheckerRegistry(0), chartPath, opts, conf)
+}
+
+// ValidateHelmChartWithRegistry validates the chart at chartPath as ValidateHelmChart does, specs are shared through
+// registry so that they are loaded once for rendering and validating every template
+func ValidateHelmChartWithRegistry(registry *pkg.KubeCheckerRegistry, chartPath string, opts HelmOptions, conf *pkg.Config) ([]HelmTemplateResult, error) {
+	sourceVersion, targetVersion := conf.SourceKubernetesVersion, conf.TargetKubernetesVersion
+	if len(sourceVersion) == 0 {
+		sourceVersion = targetVersion
+	}
+	source, err := RenderHelmChartWithRegistry(registry, chartPath, opts, sourceVersion, conf)
+	if err != nil {
+		return nil, err
+	}
+	target := source
+	if sourceVersion != targetVersion {
+		if target, err = RenderHelmChartWithRegistry(registry, chartPath, opts, targetVersion, conf); err != nil {
+			return nil, err
+		}
+	}
+	sourceManifests := map[string][]byte{}
+	for _, file := range source {
+		sourceManifests[file.Name] = file.Contents
+	}
+	var templates []string
+	targetManifests := map[string][]byte{}
+	for _, file := range target {
+		targetManifests[file.Name] = file.Contents
+		if _, ok := sourceManifests[file.Name]; !ok {
+			templates = append(templates, file.Name)
+		}
+	}
+	for _, file := range source {
+		templates = append(templates, file.Name)
+	}
+	sort.Strings(templates)
+
+	crds := append(append([]map[string]interface{}(nil), conf.CustomResourceDefinitions...),
+		templateCustomResourceDefinitions(templates, sourceManifests, targetManifests)...)
+
+	var results []HelmTemplateResult
+	var errs *multierror.Error
+	validate := func(template, releaseVersion string, manifests []byte, sourceVersion string) {
+		templateConf := *conf
+		templateConf.FileName = template
+		templateConf.SourceKubernetesVersion = sourceVersion
+		templateConf.CustomResourceDefinitions = crds
+		templateResults, err := ValidateWithRegistry(registry, manifests, &templateConf)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+		results = append(results, HelmTemplateResult{Template: template, ReleaseVersion: releaseVersion, Results: templateResults})
+	}
+	for _, template := range templates {
+		sourceManifest, inSource := sourceManifests[template]
+		targetManifest, inTarget := targetManifests[template]
+		if inSource && inTarget && bytes.Equal(sourceManifest, targetManifest) {
+			validate(template, "", sourceManifest, sourceVersion)
+			continue
+		}
+		if inSource {
+			validate(template, sourceVersion, sourceManifest, sourceVersion)
+		}
+		if inTarget {
+			validate(template, targetVersion, targetManifest, targetVersion)
+		}
+	}
+	return results, errs.ErrorOrNil()
+}
+
+// templateCustomResourceDefinitions returns CustomResourceDefinitions rendered by templates for source and target
+// releases, those of templates which render the same manifests for both releases are returned once
+func templateCustomResourceDefinitions(templates []string, sourceManifests, targetManifests map[string][]byte) []map[string]interface{} {
+	var crds []map[string]interface{}
+	for _, template := range templates {
+		sourceManifest, inSource := sourceManifests[template]
+		targetManifest, inTarget := targetManifests[template]
+		if inSource {
+			crds = append(crds, FindCustomResourceDefinitions(sourceManifest)...)
+		}
+		if inTarget && !(inSource && bytes.Equal(sourceManifest, targetManifest)) {
+			crds = append(crds, FindCustomResourceDefinitions(targetManifest)...)
+		}
+	}
+	return crds
+}
+
+// RenderHelmChart renders templates of the chart at chartPath, a directory or a packaged chart, with capabilities of
+// kubernetes release releaseVersion, ie its version and apiVersions served in it. Manifests are named by the template
+// which renders them, CRDs of the chart come first as helm installs them before templates.
+func RenderHelmChart(chartPath string, opts HelmOptions, releaseVersion string, conf *pkg.Config) ([]pkg.ManifestFile, error) {
+	return RenderHelmChartWithRegistry(pkg.NewKubeCheckerRegistry(0), chartPath, opts, releaseVersion, conf)
+}
+
+// RenderHelmChartWithRegistry renders the chart at chartPath as RenderHelmChart does, the spec of releaseVersion is
+// shared through registry
+func RenderHelmChartWithRegistry(registry *pkg.KubeCheckerRegistry, chartPath string, opts HelmOptions, releaseVersion string, conf *pkg.Config) ([]pkg.ManifestFile, error) {
+	ch, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load chart %s: %w", chartPath, err)
+	}
+	values, err := helmValues(opts)
+	if err != nil {
+		return nil, err
+	}
+	caps, err := helmCapabilities(registry, releaseVersion, conf)
+	if err != nil {
+		return nil, err
+	}
+	if len(ch.Metadata.KubeVersion) > 0 && !chartutil.IsCompatibleRange(ch.Metadata.KubeVersion, caps.KubeVersion.String()) {
+		return nil, fmt.Errorf("chart %s requires kubeVersion %s which is incompatible with kubernetes %s", ch.Name(), ch.Metadata.KubeVersion, caps.KubeVersion.String())
+	}
+	if err := chartutil.ProcessDependencies(ch, values); err != nil {
+		return nil, fmt.Errorf("unable to process dependencies of chart %s: %w", ch.Name(), err)
+	}
+	options := chartutil.ReleaseOptions{Name: opts.ReleaseName, Namespace: opts.Namespace, Revision: 1, IsInstall: true}
+	renderValues, err := chartutil.ToRenderValues(ch, values, options, caps)
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := engine.Render(ch, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("unable to render chart %s for kubernetes %s: %w", ch.Name(), releaseVersion, err)
+	}
+	var files []pkg.ManifestFile
+	for _, crd := range ch.CRDObjects() {
+		files = append(files, pkg.ManifestFile{Name: crd.Filename, Contents: crd.File.Data})
+	}
+	templates := make([]string, 0, len(rendered))
+	for template, manifest := range rendered {
+		// partials and notes do not render manifests
+		base := path.Base(template)
+		if strings.HasPrefix(base, "_") || base == "NOTES.txt" || len(strings.TrimSpace(manifest)) == 0 {
+			continue
+		}
+		templates = append(templates, template)
+	}
+	sort.Strings(templates)
+	for _, template := range templates {
+		files = append(files, pkg.ManifestFile{Name: template, Contents: []byte(rendered[template])})
+	}
+	return files, nil
+}
+
+// helmValues merges value files and --set values of opts as helm does
+func helmValues(opts HelmOptions) (chartutil.Values, error) {
+	values := map[string]interface{}{}
+	for _, file := range opts.ValueFiles {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read values file %s: %w", file, err)
+		}
+		fileValues := map[string]interface{}{}
+		if err := yaml.Unmarshal(data, &fileValues); err != nil {
+			return nil, fmt.Errorf("unable to parse values file %s: %w", file, err)
+		}
+		values = mergeValues(values, fileValues)
+	}
+	for _, value := range opts.Values {
+		if err := strvals.ParseInto(value, values); err != nil {
+			return nil, fmt.Errorf("unable to parse --set %s: %w", value, err)
+		}
+	}
+	for _, value := range opts.StringValues {
+		if err := strvals.ParseIntoString(value, values); err != nil {
+			return nil, fmt.Errorf("unable to parse --set-string %s: %w", value, err)
+		}
+	}
+	return values, nil
+}
+
+// mergeValues merges override into base recursively, values of override taking precedence
+func mergeValues(base, override map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range override {
+		if values, ok := value.(map[string]interface{}); ok {
+			if baseValues, ok := merged[key].(map[string]interface{}); ok {
+				merged[key] = mergeValues(baseValues, values)
+				continue
+			}
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// helmCapabilities returns capabilities of kubernetes release releaseVersion, apiVersions are those served in the
+// release as per its openapi spec which is shared through registry
+func helmCapabilities(registry *pkg.KubeCheckerRegistry, releaseVersion string, conf *pkg.Config) (*chartutil.Capabilities, error) {
+	kubeC, err := registry.NewKubeChecker(conf)
+	if err != nil {
+		return nil, err
+	}
+	if releaseVersion == conf.TargetKubernetesVersion && len(conf.TargetSchemaLocation) > 0 {
+		err = kubeC.LoadFromPath(releaseVersion, conf.TargetSchemaLocation, false)
+	} else if releaseVersion == conf.SourceKubernetesVersion && len(conf.SourceSchemaLocation) > 0 {
+		err = kubeC.LoadFromPath(releaseVersion, conf.SourceSchemaLocation, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+	release, err := kubeC.ResolveReleaseVersion(releaseVersion)
+	if err != nil {
+		return nil, err
+	}
+	timeline, err := kubeC.ApiLifecycleTimeline([]string{release})
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.SplitN(strings.TrimPrefix(release, "v"), ".", 3)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid kubernetes version %q", release)
+	}
+	apiVersions := make(chartutil.VersionSet, 0, 2*len(timeline.Lifecycles))
+	seen := map[string]bool{}
+	for _, lifecycle := range timeline.Lifecycles {
+		if !seen[lifecycle.APIVersion()] {
+			seen[lifecycle.APIVersion()] = true
+			apiVersions = append(apiVersions, lifecycle.APIVersion())
+		}
+		apiVersions = append(apiVersions, lifecycle.APIVersion()+"/"+lifecycle.Kind)
+	}
+	return &chartutil.Capabilities{
+		KubeVersion: chartutil.KubeVersion{Version: fmt.Sprintf("v%s.%s.0", parts[0], parts[1]), Major: parts[0], Minor: parts[1]},
+		APIVersions: apiVersions,
+		HelmVersion: chartutil.DefaultCapabilities.HelmVersion,
+	}, nil
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package kubedd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devtron-labs/silver-surfer/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+// testHelmSpec returns swagger.json serving Deployment in apiVersions
+func testHelmSpec(release string, apiVersions ...string) string {
+	var paths, definitions []string
+	for _, apiVersion := range apiVersions {
+		group, version := strings.Split(apiVersion, "/")[0], strings.Split(apiVersion, "/")[1]
+		gvk := fmt.Sprintf(`{"group": %q, "kind": "Deployment", "version": %q}`, group, version)
+		paths = append(paths, fmt.Sprintf(`"/apis/%s/deployments": {"post": {"x-kubernetes-group-version-kind": %s,
+			"responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/io.k8s.api.%s.%s.Deployment"}}}}}`, apiVersion, gvk, group, version))
+		definitions = append(definitions, fmt.Sprintf(`"io.k8s.api.%s.%s.Deployment": {"type": "object", "properties": {"apiVersion": {"type": "string"},
+			"kind": {"type": "string"}, "metadata": {"type": "object"}, "spec": {"type": "object"}}, "x-kubernetes-group-version-kind": [%s]}`, group, version, gvk))
+	}
+	return fmt.Sprintf(`{"swagger": "2.0", "info": {"title": "Kubernetes", "version": "v%s.0"}, "paths": {%s}, "definitions": {%s}}`,
+		release, strings.Join(paths, ","), strings.Join(definitions, ","))
+}
+
+const testHelmDeployment = `{{- if .Capabilities.APIVersions.Has "apps/v1beta2/Deployment" }}
+apiVersion: apps/v1beta2
+{{- else }}
+apiVersion: apps/v1
+{{- end }}
+kind: Deployment
+metadata:
+  name: {{ .Release.Name }}
+  namespace: {{ .Release.Namespace }}
+  annotations:
+    kube-version: {{ .Capabilities.KubeVersion.Version | quote }}
+    image: {{ .Values.image }}
+spec:
+  replicas: {{ .Values.replicas }}
+`
+
+func TestRenderHelmChart(t *testing.T) {
+	dir := t.TempDir()
+	for release, apiVersions := range map[string][]string{"1.15": {"apps/v1beta2", "apps/v1"}, "1.29": {"apps/v1"}} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, "specs", release), 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "specs", release, "swagger.json"), []byte(testHelmSpec(release, apiVersions...)), 0644))
+	}
+	chartDir := filepath.Join(dir, "demo")
+	for name, contents := range map[string]string{
+		"Chart.yaml":                "apiVersion: v2\nname: demo\nversion: 0.1.0\n",
+		"values.yaml":               "image: nginx\nreplicas: 1\n",
+		"templates/_helpers.tpl":    `{{- define "demo.name" -}}demo{{- end -}}`,
+		"templates/NOTES.txt":       "installed",
+		"templates/deployment.yaml": testHelmDeployment,
+		"templates/disabled.yaml":   "{{- if .Values.disabled }}\nkind: ConfigMap\n{{- end }}\n",
+		"crds/crd.yaml":             "apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\n",
+	} {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(chartDir, name)), 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(chartDir, name), []byte(contents), 0644))
+	}
+	valuesFile := filepath.Join(dir, "values.yaml")
+	assert.NoError(t, ioutil.WriteFile(valuesFile, []byte("image: httpd\nreplicas: 2\n"), 0644))
+
+	conf := pkg.NewDefaultConfig()
+	conf.SchemaLocation = filepath.Join(dir, "specs", "%s", "swagger.json")
+	conf.CacheDir = ""
+	opts := HelmOptions{ReleaseName: "web", Namespace: "apps", ValueFiles: []string{valuesFile}, Values: []string{"replicas=3"}}
+	tests := []struct {
+		release         string
+		wantAPIVersion  string
+		wantKubeVersion string
+	}{
+		{release: "1.15", wantAPIVersion: "apps/v1beta2", wantKubeVersion: "v1.15.0"},
+		{release: "1.29", wantAPIVersion: "apps/v1", wantKubeVersion: "v1.29.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.release, func(t *testing.T) {
+			files, err := RenderHelmChart(chartDir, opts, tt.release, conf)
+			assert.NoError(t, err)
+			var names []string
+			for _, file := range files {
+				names = append(names, file.Name)
+			}
+			assert.Equal(t, []string{"demo/crds/crd.yaml", "demo/templates/deployment.yaml"}, names)
+			documents, err := pkg.DecodeDocuments(files[1].Name, files[1].Contents)
+			assert.NoError(t, err)
+			object := documents[0].Object
+			assert.Equal(t, tt.wantAPIVersion, object["apiVersion"])
+			assert.Equal(t, map[string]interface{}{"name": "web", "namespace": "apps",
+				"annotations": map[string]interface{}{"kube-version": tt.wantKubeVersion, "image": "httpd"}}, object["metadata"])
+			assert.Equal(t, map[string]interface{}{"replicas": float64(3)}, object["spec"])
+		})
+	}
+
+	conf.SourceKubernetesVersion, conf.TargetKubernetesVersion = "1.15", "1.29"
+	templateResults, err := ValidateHelmChart(chartDir, opts, conf)
+	assert.NoError(t, err)
+	type template struct {
+		Template       string
+		ReleaseVersion string
+		FileName       string
+		APIVersion     string
+	}
+	var templates []template
+	for _, templateResult := range templateResults {
+		for _, result := range templateResult.Results {
+			templates = append(templates, template{Template: templateResult.Template, ReleaseVersion: templateResult.ReleaseVersion,
+				FileName: result.FileName, APIVersion: result.APIVersion})
+		}
+	}
+	assert.Equal(t, []template{
+		{Template: "demo/templates/deployment.yaml", ReleaseVersion: "1.15", FileName: "demo/templates/deployment.yaml", APIVersion: "apps/v1beta2"},
+		{Template: "demo/templates/deployment.yaml", ReleaseVersion: "1.29", FileName: "demo/templates/deployment.yaml", APIVersion: "apps/v1"},
+	}, templates)
+	assert.Equal(t, "1.15", conf.SourceKubernetesVersion)
+	assert.Empty(t, conf.CustomResourceDefinitions)
+
+	// the chart is rendered once for the target release alone, which conf is left with
+	conf.SourceKubernetesVersion = ""
+	templateResults, err = ValidateHelmChart(chartDir, opts, conf)
+	assert.NoError(t, err)
+	if assert.Len(t, templateResults, 2) {
+		assert.Equal(t, "demo/templates/deployment.yaml", templateResults[1].Template)
+		assert.Empty(t, templateResults[1].ReleaseVersion)
+	}
+	assert.Empty(t, conf.SourceKubernetesVersion)
+}
+
+func TestTemplateCustomResourceDefinitions(t *testing.T) {
+	crd := func(name string) []byte {
+		return []byte(fmt.Sprintf("apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata: {name: %s}\n", name))
+	}
+	tests := []struct {
+		name            string
+		sourceManifests map[string][]byte
+		targetManifests map[string][]byte
+		want            []string
+	}{
+		{name: "same manifests for both releases",
+			sourceManifests: map[string][]byte{"crds/crd.yaml": crd("widgets.example.com")},
+			targetManifests: map[string][]byte{"crds/crd.yaml": crd("widgets.example.com")},
+			want:            []string{"widgets.example.com"}},
+		{name: "manifests differing between releases",
+			sourceManifests: map[string][]byte{"templates/crd.yaml": crd("widgets.example.com")},
+			targetManifests: map[string][]byte{"templates/crd.yaml": crd("gadgets.example.com")},
+			want:            []string{"widgets.example.com", "gadgets.example.com"}},
+		{name: "template of target release alone",
+			sourceManifests: map[string][]byte{},
+			targetManifests: map[string][]byte{"templates/crd.yaml": crd("gadgets.example.com")},
+			want:            []string{"gadgets.example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var templates []string
+			for template := range tt.targetManifests {
+				templates = append(templates, template)
+			}
+			var names []string
+			for _, crd := range templateCustomResourceDefinitions(templates, tt.sourceManifests, tt.targetManifests) {
+				names = append(names, crd["metadata"].(map[string]interface{})["name"].(string))
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
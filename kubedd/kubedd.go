@@ -18,21 +18,43 @@
 package kubedd
 
 import (
-	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/devtron-labs/silver-surfer/pkg"
 	kLog "github.com/devtron-labs/silver-surfer/pkg/log"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"os"
 	"strings"
+	"sync"
 )
 
-var yamlSeparator = []byte("\n---\n")
-
 // Validate a Kubernetes YAML file, parsing out individual resources
-// and validating them all according to the  relevant schemas
+// and validating them all according to the  relevant schemas. Custom
+// resources are validated against conf.CustomResourceDefinitions which
+// callers collect across all their inputs using FindCustomResourceDefinitions
 func Validate(input []byte, conf *pkg.Config) ([]pkg.ValidationResult, error) {
-	kubeC := pkg.NewKubeCheckerImpl()
+	kubeC, err := pkg.NewKubeCheckerImplForConfig(conf)
+	if err != nil {
+		kLog.Error(err)
+		os.Exit(1)
+	}
+	return validateInput(kubeC, input, conf)
+}
+
+// ValidateWithRegistry validates input like Validate using specs shared through registry, so that releases are
+// loaded once while validating many inputs
+func ValidateWithRegistry(registry *pkg.KubeCheckerRegistry, input []byte, conf *pkg.Config) ([]pkg.ValidationResult, error) {
+	kubeC, err := registry.NewKubeChecker(conf)
+	if err != nil {
+		return nil, err
+	}
+	return validateInput(kubeC, input, conf)
+}
+
+func validateInput(kubeC pkg.KubeChecker, input []byte, conf *pkg.Config) ([]pkg.ValidationResult, error) {
+	if len(conf.SourceKubernetesVersion) == 0 && len(conf.TargetKubernetesVersion) != 0 {
+		conf.SourceKubernetesVersion = conf.TargetKubernetesVersion
+	}
 	if len(conf.TargetSchemaLocation) > 0 {
 		err := kubeC.LoadFromPath(conf.TargetKubernetesVersion, conf.TargetSchemaLocation, false)
 		if err != nil {
@@ -59,28 +81,98 @@ func Validate(input []byte, conf *pkg.Config) ([]pkg.ValidationResult, error) {
 			os.Exit(1)
 		}
 	}
-	if len(conf.SourceKubernetesVersion) == 0 && len(conf.TargetKubernetesVersion) != 0 {
-		conf.SourceKubernetesVersion = conf.TargetKubernetesVersion
+	for _, crd := range conf.CustomResourceDefinitions {
+		if err := kubeC.AddCustomResourceDefinition(crd); err != nil {
+			kLog.Warn(err.Error())
+		}
 	}
-	splits := bytes.Split(input, yamlSeparator)
+	documents, decodeErr := pkg.DecodeDocuments(conf.FileName, input)
 	var validationResults []pkg.ValidationResult
 	//isVersionSupported := isVersionSupported()
-	for _, split := range splits {
-		validationResult, err := kubeC.ValidateYaml(string(split), conf.TargetKubernetesVersion)
+	for _, document := range documents {
+		validationResult, err := validateAcrossReleases(kubeC, document, conf.SourceKubernetesVersion, conf.TargetKubernetesVersion)
 		if err != nil {
-			fmt.Printf("err: %v\n", err)
+			kLog.Error(&pkg.DocumentError{FileName: document.FileName, Index: document.Index, Position: document.Position, Err: err})
+			continue
+		}
+		if validationResult.SchemaNotFound && conf.IgnoreMissingSchemas {
 			continue
 		}
 		//validationResult = isVersionSupported(validationResult, kubeC, conf)
 		validationResult = pkg.FilterValidationResults(validationResult, conf)
 		validationResults = append(validationResults, validationResult)
 	}
+	annotateApiLifecycles(kubeC, validationResults, conf.SourceKubernetesVersion, conf)
+	// documents before one which can not be decoded are validated, the error tells where decoding stopped
+	return validationResults, decodeErr
+}
 
-	return validationResults, nil
+// validateAcrossReleases validates document against targetVersion, to find what breaks after the upgrade, with its
+// original side, whether it is valid today, validated against sourceVersion. Result of targetVersion alone is returned
+// if document can not be validated against sourceVersion. Document is validated against sourceVersion first so that
+// apiVersions removed by targetVersion are told apart from those unknown to both by the spec of sourceVersion.
+func validateAcrossReleases(kubeC pkg.KubeChecker, document pkg.Document, sourceVersion, targetVersion string) (pkg.ValidationResult, error) {
+	var source *pkg.ValidationResult
+	if len(sourceVersion) > 0 && !sameRelease(kubeC, sourceVersion, targetVersion) {
+		if result, err := kubeC.ValidateObject(document.Object, sourceVersion); err != nil {
+			kLog.Debug(fmt.Sprintf("unable to validate against %s: %v", sourceVersion, err))
+		} else {
+			source = &result
+		}
+	}
+	result, err := kubeC.ValidateObject(document.Object, targetVersion)
+	if err == nil && source != nil {
+		result = pkg.MergeSourceValidationResult(result, *source)
+	}
+	result.SetDocument(document)
+	return result, err
+}
+
+// sameRelease tells if versions lhs and rhs, eg v1.22.3 and 1.22, refer to the same release, versions which can not
+// be resolved are compared as they are
+func sameRelease(kubeC pkg.KubeChecker, lhs, rhs string) bool {
+	if lhs == rhs {
+		return true
+	}
+	lhsRelease, lhsErr := kubeC.ResolveReleaseVersion(lhs)
+	rhsRelease, rhsErr := kubeC.ResolveReleaseVersion(rhs)
+	return lhsErr == nil && rhsErr == nil && lhsRelease == rhsRelease
+}
+
+// FindCustomResourceDefinitions returns CustomResourceDefinitions present in YAML documents of input
+func FindCustomResourceDefinitions(input []byte) []map[string]interface{} {
+	var crds []map[string]interface{}
+	// documents which can not be decoded are reported once they are validated
+	documents, _ := pkg.DecodeDocuments("", input)
+	for _, document := range documents {
+		if pkg.IsCustomResourceDefinition(document.Object) {
+			crds = append(crds, document.Object)
+		}
+	}
+	return crds
 }
 
 func ValidateCluster(cluster *pkg.Cluster, conf *pkg.Config) ([]pkg.ValidationResult, error) {
-	kubeC := pkg.NewKubeCheckerImpl()
+	kubeC, err := pkg.NewKubeCheckerImplForConfig(conf)
+	if err != nil {
+		kLog.Error(err)
+		os.Exit(1)
+	}
+	return validateCluster(kubeC, cluster, conf)
+}
+
+// ValidateClusterWithRegistry validates cluster using specs shared through registry, so that concurrent and
+// subsequent validations do not load the same spec again
+func ValidateClusterWithRegistry(registry *pkg.KubeCheckerRegistry, cluster *pkg.Cluster, conf *pkg.Config) ([]pkg.ValidationResult, error) {
+	kubeC, err := registry.NewKubeChecker(conf)
+	if err != nil {
+		kLog.Error(err)
+		return make([]pkg.ValidationResult, 0), err
+	}
+	return validateCluster(kubeC, cluster, conf)
+}
+
+func validateCluster(kubeC pkg.KubeChecker, cluster *pkg.Cluster, conf *pkg.Config) ([]pkg.ValidationResult, error) {
 	if len(conf.TargetSchemaLocation) > 0 {
 		err := kubeC.LoadFromPath(conf.TargetKubernetesVersion, conf.TargetSchemaLocation, false)
 		if err != nil {
@@ -94,12 +186,57 @@ func ValidateCluster(cluster *pkg.Cluster, conf *pkg.Config) ([]pkg.ValidationRe
 			return make([]pkg.ValidationResult, 0), err
 		}
 	}
+	var validationResults []pkg.ValidationResult
+	//isVersionSupported := isVersionSupported()
+	serverVersion, objects := clusterObjects(kubeC, cluster, conf)
+	for _, k8sObj := range objects {
+		validationResult, err := validateAcrossReleases(kubeC, k8sObj, serverVersion, conf.TargetKubernetesVersion)
+		if err != nil {
+			kLog.Error(err)
+			continue
+		}
+		if validationResult.SchemaNotFound && conf.IgnoreMissingSchemas {
+			continue
+		}
+		//validationResult = isVersionSupported(validationResult, kubeC, conf)
+		validationResult = pkg.FilterValidationResults(validationResult, conf)
+		validationResults = append(validationResults, validationResult)
+	}
+	annotateApiLifecycles(kubeC, validationResults, serverVersion, conf)
+	return validationResults, nil
+}
+
+// clusterObjects returns server version of the cluster and documents of its objects, their last applied
+// configuration if present, after loading spec served by the cluster and its custom resource definitions into kubeC
+func clusterObjects(kubeC pkg.KubeChecker, cluster *pkg.Cluster, conf *pkg.Config) (string, []pkg.Document) {
 	serverVersion, err := cluster.ServerVersion()
 	if err != nil {
 		kLog.Error(err)
 		serverVersion = conf.TargetKubernetesVersion
 	}
 	fmt.Println("current cluster server version:- ", serverVersion)
+	if err == nil {
+		// spec served by the api server is exact for the cluster including aggregated apis and vendor specific groups,
+		// upstream spec of the server version is used if it is unavailable. Spec of the target release, loaded already
+		// if the cluster runs it, is kept so that targets are validated against the requested schema.
+		if err := kubeC.LoadFromSource(serverVersion, cluster.SchemaSource(serverVersion), false); err != nil {
+			kLog.Warn(fmt.Sprintf("unable to load openapi spec from cluster, using upstream spec of %s: %v", serverVersion, err))
+		}
+		if preferredVersions, err := cluster.PreferredVersions(); err != nil {
+			kLog.Warn(fmt.Sprintf("unable to discover preferred versions of api groups: %v", err))
+		} else {
+			kubeC.SetPreferredVersions(serverVersion, preferredVersions)
+		}
+	}
+	crds, err := cluster.FetchCustomResourceDefinitions()
+	if err != nil {
+		kLog.Warn(fmt.Sprintf("unable to fetch custom resource definitions, custom resources will not be validated: %v", err))
+	}
+	for _, crd := range append(conf.CustomResourceDefinitions, crds...) {
+		if err := kubeC.AddCustomResourceDefinition(crd); err != nil {
+			kLog.Warn(err.Error())
+		}
+	}
 	resources, err := kubeC.GetKinds(serverVersion)
 	if err != nil {
 		kLog.Error(err)
@@ -107,12 +244,11 @@ func ValidateCluster(cluster *pkg.Cluster, conf *pkg.Config) ([]pkg.ValidationRe
 		resources, err = kubeC.GetKinds(conf.TargetKubernetesVersion)
 		if err != nil {
 			kLog.Error(err)
-			return make([]pkg.ValidationResult, 0), nil
+			return serverVersion, nil
 		}
 	}
 	objects := cluster.FetchK8sObjects(resources, conf)
-	var validationResults []pkg.ValidationResult
-	//isVersionSupported := isVersionSupported()
+	var documents []pkg.Document
 	for _, obj := range objects {
 		annotations := obj.GetAnnotations()
 		k8sObj := ""
@@ -131,19 +267,187 @@ func ValidateCluster(cluster *pkg.Cluster, conf *pkg.Config) ([]pkg.ValidationRe
 			}
 			k8sObj = string(bt)
 		}
-		if len(k8sObj) > 0 {
-			validationResult, err := kubeC.ValidateJson(k8sObj, conf.TargetKubernetesVersion)
-			if err != nil {
-				fmt.Printf("err: %v\n", err)
-				continue
+		object := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(k8sObj), &object); err != nil {
+			kLog.Debug(fmt.Sprintf("%v", err))
+			continue
+		}
+		documents = append(documents, pkg.Document{Object: object})
+	}
+	return serverVersion, documents
+}
+
+// annotateApiLifecycles sets releases in which deprecated and removed apiVersions of results are deprecated and
+// removed, as found across every release from sourceVersion to conf.TargetKubernetesVersion, if conf.ApiLifecycles
+// is set. Only source and target releases are used if specs are read from files, which hold a single release, or if
+// intermediate releases can not be loaded.
+func annotateApiLifecycles(kubeC pkg.KubeChecker, results []pkg.ValidationResult, sourceVersion string, conf *pkg.Config) {
+	if !conf.ApiLifecycles {
+		return
+	}
+	affected := false
+	for _, result := range results {
+		affected = affected || result.Deprecated || result.Deleted
+	}
+	if !affected {
+		return
+	}
+	if len(sourceVersion) == 0 {
+		sourceVersion = conf.TargetKubernetesVersion
+	}
+	var releases []string
+	var err error
+	ends := []string{sourceVersion, conf.TargetKubernetesVersion}
+	if isSchemaFile(conf.SourceSchemaLocation) || isSchemaFile(conf.TargetSchemaLocation) {
+		releases = ends
+	} else if releases, err = pkg.ParseUpgradePath(sourceVersion + ".." + conf.TargetKubernetesVersion); err != nil {
+		releases = ends
+	} else if err := loadReleases(kubeC, releases, conf); err != nil {
+		releases = ends
+	}
+	timeline, err := kubeC.ApiLifecycleTimeline(releases)
+	if err != nil {
+		kLog.Warn(fmt.Sprintf("unable to find releases in which apiVersions are deprecated and removed: %v", err))
+		return
+	}
+	for i := range results {
+		timeline.Annotate(&results[i])
+	}
+}
+
+// isSchemaFile tells if location is a spec of a single release rather than a schema bundle
+func isSchemaFile(location string) bool {
+	return len(location) > 0 && !pkg.IsSchemaBundle(location)
+}
+
+// ApiLifecycleTimeline returns lifecycle of every group/version/kind served in any of releases, specs are loaded
+// from the schema locations of conf
+func ApiLifecycleTimeline(releases []string, conf *pkg.Config) (*pkg.ApiLifecycleTimeline, error) {
+	kubeC, err := pkg.NewKubeCheckerImplForConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadReleases(kubeC, releases, conf); err != nil {
+		return nil, err
+	}
+	return kubeC.ApiLifecycleTimeline(releases)
+}
+
+// DiffSchemas compares kinds served in kubernetes release from and their schemas with those of release to, specs are
+// loaded from the schema locations of conf
+func DiffSchemas(from, to string, conf *pkg.Config) (*pkg.SchemaDiff, error) {
+	kubeC, err := pkg.NewKubeCheckerImplForConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadReleases(kubeC, []string{from, to}, conf); err != nil {
+		return nil, err
+	}
+	return kubeC.DiffSchemas(from, to)
+}
+
+// ValidateUpgradePath validates Kubernetes YAML documents of input against every release of conf.UpgradePath, so
+// that resources blocking any step of the upgrade are found upfront. Custom resources are validated against
+// conf.CustomResourceDefinitions as in Validate
+func ValidateUpgradePath(input []byte, conf *pkg.Config) ([]pkg.UpgradePathResult, error) {
+	kubeC, err := pkg.NewKubeCheckerImplForConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	return validateUpgradePathInput(kubeC, input, conf)
+}
+
+// ValidateUpgradePathWithRegistry validates input like ValidateUpgradePath using specs shared through registry, so
+// that releases are loaded once while validating many inputs
+func ValidateUpgradePathWithRegistry(registry *pkg.KubeCheckerRegistry, input []byte, conf *pkg.Config) ([]pkg.UpgradePathResult, error) {
+	kubeC, err := registry.NewKubeChecker(conf)
+	if err != nil {
+		return nil, err
+	}
+	return validateUpgradePathInput(kubeC, input, conf)
+}
+
+func validateUpgradePathInput(kubeC pkg.KubeChecker, input []byte, conf *pkg.Config) ([]pkg.UpgradePathResult, error) {
+	releases, err := pkg.ParseUpgradePath(conf.UpgradePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadReleases(kubeC, releases, conf); err != nil {
+		return nil, err
+	}
+	for _, crd := range conf.CustomResourceDefinitions {
+		if err := kubeC.AddCustomResourceDefinition(crd); err != nil {
+			kLog.Warn(err.Error())
+		}
+	}
+	documents, err := pkg.DecodeDocuments(conf.FileName, input)
+	return validateUpgradePath(kubeC, documents, releases, conf), err
+}
+
+// ValidateClusterUpgradePath validates objects of cluster against every release of conf.UpgradePath
+func ValidateClusterUpgradePath(cluster *pkg.Cluster, conf *pkg.Config) ([]pkg.UpgradePathResult, error) {
+	releases, err := pkg.ParseUpgradePath(conf.UpgradePath)
+	if err != nil {
+		return nil, err
+	}
+	kubeC, err := pkg.NewKubeCheckerImplForConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadReleases(kubeC, releases, conf); err != nil {
+		return nil, err
+	}
+	_, objects := clusterObjects(kubeC, cluster, conf)
+	return validateUpgradePath(kubeC, objects, releases, conf), nil
+}
+
+// loadReleases loads specs of releases concurrently, from conf.TargetSchemaLocation if it is a schema bundle
+func loadReleases(kubeC pkg.KubeChecker, releases []string, conf *pkg.Config) error {
+	errs := make([]error, len(releases))
+	var wg sync.WaitGroup
+	for i, release := range releases {
+		wg.Add(1)
+		go func(i int, release string) {
+			defer wg.Done()
+			if pkg.IsSchemaBundle(conf.TargetSchemaLocation) {
+				errs[i] = kubeC.LoadFromPath(release, conf.TargetSchemaLocation, false)
+			} else {
+				errs[i] = kubeC.LoadFromUrl(release, false)
 			}
-			//validationResult = isVersionSupported(validationResult, kubeC, conf)
-			validationResult = pkg.FilterValidationResults(validationResult, conf)
-			validationResults = append(validationResults, validationResult)
+		}(i, release)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("unable to load openapi spec of %s: %w", releases[i], err)
 		}
 	}
+	return nil
+}
 
-	return validationResults, nil
+func validateUpgradePath(kubeC pkg.KubeChecker, documents []pkg.Document, releases []string, conf *pkg.Config) []pkg.UpgradePathResult {
+	var upgradePathResults []pkg.UpgradePathResult
+	for _, document := range documents {
+		results := make([]pkg.ValidationResult, 0, len(releases))
+		schemaNotFound := true
+		for _, release := range releases {
+			validationResult, err := kubeC.ValidateObject(document.Object, release)
+			if err != nil {
+				kLog.Error(&pkg.DocumentError{FileName: document.FileName, Index: document.Index, Position: document.Position, Err: err})
+				break
+			}
+			validationResult.SetDocument(document)
+			schemaNotFound = schemaNotFound && validationResult.SchemaNotFound
+			results = append(results, pkg.FilterValidationResults(validationResult, conf))
+		}
+		if schemaNotFound && conf.IgnoreMissingSchemas {
+			continue
+		}
+		if len(results) == len(releases) {
+			upgradePathResults = append(upgradePathResults, pkg.AnalyzeUpgradePath(releases, results))
+		}
+	}
+	return upgradePathResults
 }
 
 //func isVersionSupported() func(result pkg.ValidationResult, kubeC pkg.KubeChecker, conf *pkg.Config) pkg.ValidationResult {
@@ -3,6 +3,13 @@ package kubedd
 import (
 	"fmt"
 	"github.com/devtron-labs/silver-surfer/pkg"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"k8s.io/client-go/rest"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -51,3 +58,199 @@ func TestValidateCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterObjectsKeepsTargetSpec(t *testing.T) {
+	tests := []struct {
+		name          string
+		serverVersion string
+		// wantClusterSpec tells whether spec of the server version is the one served by the cluster
+		wantClusterSpec bool
+	}{
+		{name: "cluster running target release", serverVersion: "29"},
+		{name: "cluster running older release", serverVersion: "28", wantClusterSpec: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/version":
+					w.Write([]byte(fmt.Sprintf(`{"major": "1", "minor": %q, "gitVersion": "v1.%s.3"}`, tt.serverVersion, tt.serverVersion)))
+				case "/openapi/v2":
+					w.Write([]byte(testHelmSpec("1."+tt.serverVersion, "apps/v1", "apps/v1beta2")))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+			targetSpec := filepath.Join(t.TempDir(), "swagger.json")
+			assert.NoError(t, ioutil.WriteFile(targetSpec, []byte(testHelmSpec("1.29", "apps/v1")), 0644))
+			conf := pkg.NewDefaultConfig()
+			conf.CacheDir = ""
+			conf.TargetKubernetesVersion = "1.29"
+			kubeC, err := pkg.NewKubeCheckerImplForConfig(conf)
+			assert.NoError(t, err)
+			assert.NoError(t, kubeC.LoadFromPath(conf.TargetKubernetesVersion, targetSpec, false))
+
+			serverVersion, _ := clusterObjects(kubeC, pkg.NewClusterFromEnvOrConfig(&rest.Config{Host: server.URL}), conf)
+			assert.Equal(t, "1."+tt.serverVersion, serverVersion)
+			assert.Equal(t, tt.wantClusterSpec, kubeC.IsApiVersionSupported(serverVersion, "apps/v1beta2", "Deployment"))
+			assert.False(t, kubeC.IsApiVersionSupported(conf.TargetKubernetesVersion, "apps/v1beta2", "Deployment"))
+		})
+	}
+}
+
+// crdCountingKubeChecker counts CustomResourceDefinitions registered with the checker it wraps
+type crdCountingKubeChecker struct {
+	pkg.KubeChecker
+	crds []string
+}
+
+func (k *crdCountingKubeChecker) AddCustomResourceDefinition(crd map[string]interface{}) error {
+	k.crds = append(k.crds, crd["metadata"].(map[string]interface{})["name"].(string))
+	return k.KubeChecker.AddCustomResourceDefinition(crd)
+}
+
+func TestValidateUpgradePathRegistersCRDsOnce(t *testing.T) {
+	dir := t.TempDir()
+	for _, release := range []string{"1.28", "1.29"} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, release), 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, release, "swagger.json"), []byte(testHelmSpec(release, "apps/v1")), 0644))
+	}
+	input := []byte(`apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names: {kind: Widget, plural: widgets}
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema: {type: object}
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata: {name: w}
+`)
+	conf := pkg.NewDefaultConfig()
+	conf.CacheDir = ""
+	conf.SchemaLocation = filepath.Join(dir, "%s", "swagger.json")
+	conf.UpgradePath = "1.28..1.29"
+	// CRDs are collected across all inputs upfront as main does
+	conf.CustomResourceDefinitions = FindCustomResourceDefinitions(input)
+	checker, err := pkg.NewKubeCheckerImplForConfig(conf)
+	assert.NoError(t, err)
+	kubeC := &crdCountingKubeChecker{KubeChecker: checker}
+	results, err := validateUpgradePathInput(kubeC, input, conf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"widgets.example.com"}, kubeC.crds)
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "Widget", results[1].Kind)
+	}
+}
+
+func TestValidateApiLifecycles(t *testing.T) {
+	dir := t.TempDir()
+	for release, apiVersions := range map[string][]string{"1.15": {"apps/v1beta2", "apps/v1"}, "1.29": {"apps/v1"}} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, release+".json"), []byte(testHelmSpec(release, apiVersions...)), 0644))
+	}
+	input := []byte("apiVersion: apps/v1beta2\nkind: Deployment\nmetadata: {name: d, namespace: default}\nspec: {}\n")
+	tests := []struct {
+		name          string
+		apiLifecycles bool
+		wantRemovedIn string
+	}{
+		{name: "without api lifecycles"},
+		// specs read from files are of source and target versions alone, intermediate releases are not downloaded
+		{name: "with api lifecycles", apiLifecycles: true, wantRemovedIn: "1.29"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := pkg.NewDefaultConfig()
+			conf.CacheDir = ""
+			conf.SourceKubernetesVersion = "1.15"
+			conf.TargetKubernetesVersion = "1.29"
+			conf.SourceSchemaLocation = filepath.Join(dir, "1.15.json")
+			conf.TargetSchemaLocation = filepath.Join(dir, "1.29.json")
+			conf.ApiLifecycles = tt.apiLifecycles
+			results, err := ValidateWithRegistry(pkg.NewKubeCheckerRegistry(0), input, conf)
+			assert.NoError(t, err)
+			if assert.Len(t, results, 1) {
+				assert.True(t, results[0].Deleted)
+				assert.Equal(t, tt.wantRemovedIn, results[0].RemovedInRelease)
+			}
+		})
+	}
+}
+
+// releaseRecordingKubeChecker records releases objects are validated against by the checker it wraps
+type releaseRecordingKubeChecker struct {
+	pkg.KubeChecker
+	releases []string
+}
+
+func (k *releaseRecordingKubeChecker) ValidateObject(object map[string]interface{}, releaseVersion string) (pkg.ValidationResult, error) {
+	k.releases = append(k.releases, releaseVersion)
+	return k.KubeChecker.ValidateObject(object, releaseVersion)
+}
+
+func TestValidateAcrossReleases(t *testing.T) {
+	dir := t.TempDir()
+	for _, release := range []string{"1.28", "1.29"} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, release+".json"), []byte(testHelmSpec(release, "apps/v1")), 0644))
+	}
+	tests := []struct {
+		name          string
+		sourceVersion string
+		wantReleases  []string
+	}{
+		{name: "source release", sourceVersion: "1.28", wantReleases: []string{"1.28", "1.29"}},
+		{name: "patch release of target", sourceVersion: "v1.29.3", wantReleases: []string{"1.29"}},
+		{name: "target release", sourceVersion: "1.29", wantReleases: []string{"1.29"}},
+		{name: "no source release", wantReleases: []string{"1.29"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := pkg.NewDefaultConfig()
+			conf.CacheDir = ""
+			checker, err := pkg.NewKubeCheckerImplForConfig(conf)
+			assert.NoError(t, err)
+			for _, release := range []string{"1.28", "1.29"} {
+				assert.NoError(t, checker.LoadFromPath(release, filepath.Join(dir, release+".json"), false))
+			}
+			kubeC := &releaseRecordingKubeChecker{KubeChecker: checker}
+			document := pkg.Document{Object: map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment",
+				"metadata": map[string]interface{}{"name": "d"}}}
+			result, err := validateAcrossReleases(kubeC, document, tt.sourceVersion, "1.29")
+			assert.NoError(t, err)
+			assert.Equal(t, "Deployment", result.Kind)
+			assert.Equal(t, tt.wantReleases, kubeC.releases)
+		})
+	}
+}
+
+func TestValidateRemovedKindOfTargetRelease(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "1.25.json"), []byte(testHelmSpec("1.25", "apps/v1")), 0644))
+	input := []byte("apiVersion: policy/v1beta1\nkind: PodSecurityPolicy\nmetadata: {name: p}\nspec: {}\n")
+	for _, ignoreMissingSchemas := range []bool{false, true} {
+		t.Run(fmt.Sprintf("ignore missing schemas %v", ignoreMissingSchemas), func(t *testing.T) {
+			// source defaults to target, no spec of an earlier release is loaded
+			conf := pkg.NewDefaultConfig()
+			conf.CacheDir = ""
+			conf.TargetKubernetesVersion = "1.25"
+			conf.TargetSchemaLocation = filepath.Join(dir, "1.25.json")
+			conf.SchemaLocation = t.TempDir()
+			conf.IgnoreMissingSchemas = ignoreMissingSchemas
+			results, err := ValidateWithRegistry(pkg.NewKubeCheckerRegistry(0), input, conf)
+			assert.NoError(t, err)
+			if assert.Len(t, results, 1) {
+				assert.True(t, results[0].Deleted)
+				assert.False(t, results[0].SchemaNotFound)
+			}
+		})
+	}
+}
@@ -49,6 +49,8 @@ var (
 	// forceColor tells kubedd to use colored output even if
 	// stdout is not a TTY
 	forceColor bool
+	// stdinFileName is the file argument of the manifest stream read from stdin
+	stdinFileName = "-"
 
 	config = pkg.NewDefaultConfig()
 )
@@ -75,6 +77,7 @@ var RootCmd = &cobra.Command{
 	Short:   "Validates migration of Kubernetes YAML file to specific kubernetes version",
 	Long:    `Validates migration of Kubernetes YAML file to specific kubernetes version, It provides details of issues with the kubernetes object in case they are migrated to cluster with newer kubernetes version`,
 	Version: fmt.Sprintf("Version: %s\nCommit: %s\nDate: %s\n", version, commit, date),
+	Args:    cobra.ArbitraryArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		if config.IgnoreMissingSchemas && !config.Quiet {
 			log2.Warn("Set to ignore missing schemas")
@@ -103,7 +106,9 @@ var RootCmd = &cobra.Command{
 		//	log.Error(errors.New("at least one file or one directory or kubeconfig path should be passed as argument"))
 		//	os.Exit(1)
 		//}
-		if len(args) > 0 || len(directories) > 0 {
+		if len(config.UpgradePath) > 0 {
+			success = processUpgradePath(args)
+		} else if len(args) > 0 || len(directories) > 0 {
 			// code flow will enter here when --directories is provided in the command
 			success = processFiles(args)
 		} else {
@@ -123,28 +128,32 @@ func processFiles(args []string) bool {
 		log2.Error(err)
 		success = false
 	}
+	manifestFiles, ok := readManifestFiles(files)
+	success = success && ok
+
+	// CRDs may live in files other than their custom resources hence they are collected upfront
+	for _, manifestFile := range manifestFiles {
+		config.CustomResourceDefinitions = append(config.CustomResourceDefinitions, kubedd.FindCustomResourceDefinitions(manifestFile.Contents)...)
+	}
 
 	var aggResults []pkg.ValidationResult
-	for _, fileName := range files {
-		filePath, _ := filepath.Abs(fileName)
-		fileContents, err := ioutil.ReadFile(filePath)
-		if err != nil {
-			log2.Error(fmt.Errorf("Could not open file %v", fileName))
-			earlyExit()
-			success = false
-			continue
-		}
-		config.FileName = fileName
-		results, err := kubedd.Validate(fileContents, config)
+	// releases are loaded once for all the files
+	registry := pkg.NewKubeCheckerRegistry(0)
+	for _, manifestFile := range manifestFiles {
+		config.FileName = manifestFile.Name
+		results, err := kubedd.ValidateWithRegistry(registry, manifestFile.Contents, config)
 		if err != nil {
 			log2.Error(err)
 			earlyExit()
 			success = false
-			continue
+			// documents before one which can not be decoded are validated
+			if len(results) == 0 {
+				continue
+			}
 		}
 
 		fmt.Println("")
-		fmt.Printf("Results for file %s\n", fileName)
+		fmt.Printf("Results for file %s\n", manifestFile.Name)
 		fmt.Println("-------------------------------------------")
 		outputManager.PutBulk(results)
 
@@ -191,14 +200,69 @@ func processCluster() bool {
 	return success
 }
 
+// processUpgradePath validates files, or the cluster if no file is passed, against every release of the upgrade path
+func processUpgradePath(args []string) bool {
+	releases, err := pkg.ParseUpgradePath(config.UpgradePath)
+	if err != nil {
+		log2.Error(err)
+		return false
+	}
+	var results []pkg.UpgradePathResult
+	success := true
+	if len(args) > 0 || len(directories) > 0 {
+		files, err := aggregateFiles(args)
+		if err != nil {
+			log2.Error(err)
+			success = false
+		}
+		manifestFiles, ok := readManifestFiles(files)
+		success = success && ok
+		for _, manifestFile := range manifestFiles {
+			config.CustomResourceDefinitions = append(config.CustomResourceDefinitions, kubedd.FindCustomResourceDefinitions(manifestFile.Contents)...)
+		}
+		// releases are loaded once for all the files
+		registry := pkg.NewKubeCheckerRegistry(0)
+		for _, manifestFile := range manifestFiles {
+			config.FileName = manifestFile.Name
+			fileResults, err := kubedd.ValidateUpgradePathWithRegistry(registry, manifestFile.Contents, config)
+			if err != nil {
+				log2.Error(err)
+				earlyExit()
+				success = false
+			}
+			results = append(results, fileResults...)
+		}
+	} else {
+		cluster := pkg.NewCluster(kubeconfig, kubecontext)
+		if results, err = kubedd.ValidateClusterUpgradePath(cluster, config); err != nil {
+			log2.Error(err)
+			return false
+		}
+	}
+
+	fmt.Println("")
+	fmt.Printf("Results for upgrade path %s\n", strings.Join(releases, " -> "))
+	fmt.Println("-------------------------------------------")
+	if err := pkg.PutUpgradePathResults(results, releases, config.OutputFormat, noColor); err != nil {
+		log2.Error(err)
+		success = false
+	}
+	for _, result := range results {
+		if result.HasBlockers() || result.HasMissingSchema() {
+			success = false
+		}
+	}
+	return success
+}
+
 // hasErrors returns truthy if any of the provided results
 // contain errors.
 func hasErrors(res []pkg.ValidationResult) bool {
 	for _, r := range res {
-		if r.Deleted || r.Deprecated {
+		if r.Deleted || r.Deprecated || r.SchemaNotFound {
 			return true
 		}
-		if len(r.ErrorsForOriginal) > 0 || len(r.ErrorsForLatest) > 0 {
+		if len(r.ErrorsForOriginal) > 0 || len(r.ErrorsForLatest) > 0 || len(r.UnknownFields) > 0 || r.HasRuleErrors() || r.HasBehaviourChangeErrors() {
 			return true
 		}
 	}
@@ -233,7 +297,7 @@ func aggregateFiles(args []string) ([]string, error) {
 			if err != nil {
 				return err
 			}
-			if !info.IsDir() && (strings.HasSuffix(info.Name(), ".yaml") || strings.HasSuffix(info.Name(), ".yml")) && !ignored {
+			if !info.IsDir() && (pkg.IsManifestFile(info.Name()) || pkg.IsManifestArchive(info.Name())) && !ignored {
 				files = append(files, path)
 			}
 			return nil
@@ -246,6 +310,49 @@ func aggregateFiles(args []string) ([]string, error) {
 	return files, allErrors.ErrorOrNil()
 }
 
+// readManifestFiles reads files, manifest files of archives and the stream of stdin if a file is -. Files which can
+// not be read are reported and skipped, false is returned if any of them is.
+func readManifestFiles(files []string) ([]pkg.ManifestFile, bool) {
+	success := true
+	// config.FileName is replaced by name of every file once it is validated
+	stdinName := config.FileName
+	var manifestFiles []pkg.ManifestFile
+	for _, fileName := range files {
+		if fileName == stdinFileName {
+			contents, err := ioutil.ReadAll(os.Stdin)
+			if err != nil {
+				log2.Error(fmt.Errorf("Could not read stdin: %w", err))
+				earlyExit()
+				success = false
+				continue
+			}
+			manifestFiles = append(manifestFiles, pkg.ManifestFile{Name: stdinName, Contents: contents})
+			continue
+		}
+		if pkg.IsManifestArchive(fileName) {
+			archiveFiles, err := pkg.ReadManifestArchive(fileName, isIgnored)
+			if err != nil {
+				log2.Error(err)
+				earlyExit()
+				success = false
+				continue
+			}
+			manifestFiles = append(manifestFiles, archiveFiles...)
+			continue
+		}
+		filePath, _ := filepath.Abs(fileName)
+		contents, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			log2.Error(fmt.Errorf("Could not open file %v", fileName))
+			earlyExit()
+			success = false
+			continue
+		}
+		manifestFiles = append(manifestFiles, pkg.ManifestFile{Name: fileName, Contents: contents})
+	}
+	return manifestFiles, success
+}
+
 func earlyExit() {
 	if config.ExitOnError {
 		os.Exit(1)
@@ -266,8 +373,9 @@ func init() {
 	if strings.HasPrefix(rootCmdName, "kubectl-") {
 		rootCmdName = strings.Replace(rootCmdName, "-", " ", 1)
 	}
-	RootCmd.Use = fmt.Sprintf("%s <file> [file...]", rootCmdName)
+	RootCmd.Use = fmt.Sprintf("%s <file|archive|-> [file...]", rootCmdName)
 	pkg.AddKubeaddFlags(RootCmd, config)
+	RootCmd.Flags().StringVarP(&config.FileName, "filename", "f", "stdin", "Filename to be displayed when testing manifests read from stdin, which is read if - is passed as a file")
 	RootCmd.Flags().BoolVarP(&forceColor, "force-color", "", false, "Force colored output even if stdout is not a TTY")
 	RootCmd.Flags().BoolVarP(&noColor, "no-color", "", false, "Display results without color")
 	RootCmd.SetVersionTemplate(`{{.Version}}`)
@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devtron-labs/silver-surfer/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadManifestFilesFromStdin(t *testing.T) {
+	dir := t.TempDir()
+	stdinContents := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata: {name: from-stdin}\n")
+	stdin := filepath.Join(dir, "stdin")
+	assert.NoError(t, ioutil.WriteFile(stdin, stdinContents, 0644))
+	fileContents := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata: {name: from-file}\n")
+	file := filepath.Join(dir, "cm.yaml")
+	assert.NoError(t, ioutil.WriteFile(file, fileContents, 0644))
+
+	f, err := os.Open(stdin)
+	assert.NoError(t, err)
+	defer f.Close()
+	defer func(stdin *os.File, fileName string) {
+		os.Stdin, config.FileName = stdin, fileName
+	}(os.Stdin, config.FileName)
+	os.Stdin = f
+	config.FileName = "deployment.yaml"
+
+	manifestFiles, ok := readManifestFiles([]string{"-", file})
+	assert.True(t, ok)
+	assert.Equal(t, []pkg.ManifestFile{
+		{Name: "deployment.yaml", Contents: stdinContents},
+		{Name: file, Contents: fileContents},
+	}, manifestFiles)
+}
@@ -0,0 +1,211 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"encoding/json"
+	"k8s.io/apimachinery/pkg/version"
+	"sort"
+	"strings"
+)
+
+// ApiLifecycle tells in which releases of a timeline a group/version/kind is introduced, deprecated and removed.
+// Releases are the first ones of the timeline in which the change is seen, so they are exact only if the timeline
+// has every minor release.
+type ApiLifecycle struct {
+	Group               string `json:"group"`
+	Version             string `json:"version"`
+	Kind                string `json:"kind"`
+	IntroducedInRelease string `json:"introducedInRelease"`
+	DeprecatedInRelease string `json:"deprecatedInRelease,omitempty"`
+	RemovedInRelease    string `json:"removedInRelease,omitempty"`
+	// ReplacementAPIVersion is the apiVersion recommended in place of a deprecated or removed one
+	ReplacementAPIVersion string `json:"replacementAPIVersion,omitempty"`
+}
+
+// APIVersion returns group/version of the lifecycle, just version for the core group
+func (l *ApiLifecycle) APIVersion() string {
+	return groupVersion(l.Group, l.Version)
+}
+
+// ApiLifecycleTimeline holds lifecycle of every group/version/kind served in any of its releases
+type ApiLifecycleTimeline struct {
+	Releases   []string       `json:"releases"`
+	Lifecycles []ApiLifecycle `json:"lifecycles"`
+}
+
+// servedApi is a group/version/kind served in a release
+type servedApi struct {
+	group      string
+	version    string
+	kind       string
+	deprecated bool
+}
+
+func groupVersion(group, version string) string {
+	if len(group) == 0 {
+		return version
+	}
+	return group + "/" + version
+}
+
+func apiLifecycleKey(apiVersion, kind string) string {
+	return strings.ToLower(apiVersion + "/" + kind)
+}
+
+// newApiLifecycleTimeline builds lifecycles from apis served in each of releases, which are sorted oldest first
+func newApiLifecycleTimeline(releases []string, apis [][]servedApi) *ApiLifecycleTimeline {
+	timeline := &ApiLifecycleTimeline{Releases: releases, Lifecycles: make([]ApiLifecycle, 0)}
+	index := map[string]int{}
+	for i, release := range releases {
+		served := map[string]bool{}
+		for _, api := range apis[i] {
+			key := apiLifecycleKey(groupVersion(api.group, api.version), api.kind)
+			served[key] = true
+			position, ok := index[key]
+			if !ok {
+				position = len(timeline.Lifecycles)
+				index[key] = position
+				timeline.Lifecycles = append(timeline.Lifecycles, ApiLifecycle{Group: api.group, Version: api.version,
+					Kind: api.kind, IntroducedInRelease: release})
+			}
+			lifecycle := &timeline.Lifecycles[position]
+			if api.deprecated && len(lifecycle.DeprecatedInRelease) == 0 {
+				lifecycle.DeprecatedInRelease = release
+			}
+			// an api served again after removal, as in a timeline with master, is not removed after all
+			lifecycle.RemovedInRelease = ""
+		}
+		for key, position := range index {
+			if !served[key] && len(timeline.Lifecycles[position].RemovedInRelease) == 0 {
+				timeline.Lifecycles[position].RemovedInRelease = release
+			}
+		}
+	}
+	for i := range timeline.Lifecycles {
+		lifecycle := &timeline.Lifecycles[i]
+		if len(lifecycle.DeprecatedInRelease) > 0 || len(lifecycle.RemovedInRelease) > 0 {
+			lifecycle.ReplacementAPIVersion = timeline.replacement(lifecycle, apis)
+		}
+	}
+	sort.SliceStable(timeline.Lifecycles, func(i, j int) bool {
+		lhs, rhs := timeline.Lifecycles[i], timeline.Lifecycles[j]
+		if !strings.EqualFold(lhs.Kind, rhs.Kind) {
+			return strings.ToLower(lhs.Kind) < strings.ToLower(rhs.Kind)
+		}
+		if lhs.Group != rhs.Group {
+			return lhs.Group < rhs.Group
+		}
+		return compareVersion(lhs.Version, rhs.Version)
+	})
+	return timeline
+}
+
+// replacement returns the apiVersion which kind of lifecycle is migrated to, in the last release of the timeline
+// which serves the kind in a group of its lineage, see apiGroupLineage. It is the highest priority version which is
+// neither deprecated nor lifecycle itself, groups the kind moved to are tried first.
+func (t *ApiLifecycleTimeline) replacement(lifecycle *ApiLifecycle, apis [][]servedApi) string {
+	lineage := apiGroupLineage(lifecycle.Group, lifecycle.Kind)
+	for i := len(t.Releases) - 1; i >= 0; i-- {
+		for _, group := range lineage {
+			var latest *servedApi
+			for j, api := range apis[i] {
+				if api.group != group || !strings.EqualFold(api.kind, lifecycle.Kind) || api.deprecated ||
+					(api.group == lifecycle.Group && api.version == lifecycle.Version) {
+					continue
+				}
+				if latest == nil || version.CompareKubeAwareVersionStrings(api.version, latest.version) > 0 {
+					latest = &apis[i][j]
+				}
+			}
+			if latest != nil {
+				return groupVersion(latest.group, latest.version)
+			}
+		}
+	}
+	return ""
+}
+
+// Lookup returns lifecycle of kind of apiVersion
+func (t *ApiLifecycleTimeline) Lookup(apiVersion, kind string) (ApiLifecycle, bool) {
+	key := apiLifecycleKey(apiVersion, kind)
+	for _, lifecycle := range t.Lifecycles {
+		if apiLifecycleKey(lifecycle.APIVersion(), lifecycle.Kind) == key {
+			return lifecycle, true
+		}
+	}
+	return ApiLifecycle{}, false
+}
+
+// Explain returns lifecycles matching query which is either a kind, eg Deployment, or apiVersion/kind eg
+// apps/v1/Deployment, kinds are matched case insensitively
+func (t *ApiLifecycleTimeline) Explain(query string) []ApiLifecycle {
+	apiVersion, kind := "", query
+	if i := strings.LastIndex(query, "/"); i >= 0 {
+		apiVersion, kind = query[:i], query[i+1:]
+	}
+	lifecycles := make([]ApiLifecycle, 0)
+	for _, lifecycle := range t.Lifecycles {
+		if !strings.EqualFold(lifecycle.Kind, kind) {
+			continue
+		}
+		if len(apiVersion) > 0 && !strings.EqualFold(lifecycle.APIVersion(), apiVersion) {
+			continue
+		}
+		lifecycles = append(lifecycles, lifecycle)
+	}
+	return lifecycles
+}
+
+// Annotate sets releases in which apiVersion of result is deprecated and removed as per the timeline
+func (t *ApiLifecycleTimeline) Annotate(result *ValidationResult) {
+	lifecycle, ok := t.Lookup(result.APIVersion, result.Kind)
+	if !ok {
+		return
+	}
+	result.DeprecatedInRelease = lifecycle.DeprecatedInRelease
+	result.RemovedInRelease = lifecycle.RemovedInRelease
+}
+
+// servedApis returns every group/version/kind which the api server of the spec serves, kinds are found deprecated by
+// rules applying to them in releaseVersion or by descriptions of their schemas if no rule applies
+func (ks *kubeSpec) servedApis(rules *DeprecationRules, releaseVersion string) []servedApi {
+	var apis []servedApi
+	for kind, kindInfos := range ks.kindInfoMap {
+		for _, ki := range kindInfos {
+			if len(ki.RestPath) == 0 {
+				continue
+			}
+			api := servedApi{group: ki.Group, version: ki.Version, kind: kind, deprecated: ki.Deprecated}
+			if scm, err := ks.schemaLookup(ki.ComponentKey); err == nil {
+				// kinds are kept in lower case in kindInfoMap, actual case is in the group version kind extension
+				if gvk, ok := scm.Extensions["x-kubernetes-group-version-kind"].(json.RawMessage); ok {
+					if gvks, err := parseGVK(gvk); err == nil && strings.EqualFold(gvks["kind"], kind) {
+						api.kind = gvks["kind"]
+					}
+				}
+				deprecations := &deprecationCheck{rules: rules, apiVersion: groupVersion(api.group, api.version), kind: api.kind, releaseVersion: releaseVersion}
+				if deprecations.isDeprecatedKind(scm) {
+					api.deprecated = true
+				}
+			}
+			apis = append(apis, api)
+		}
+	}
+	return apis
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testApiLifecycleTimeline() *ApiLifecycleTimeline {
+	releases := []string{"1.20", "1.21", "1.22", "1.25"}
+	apis := [][]servedApi{
+		{
+			{group: "batch", version: "v1beta1", kind: "CronJob"},
+			{group: "policy", version: "v1beta1", kind: "PodSecurityPolicy"},
+			{group: "networking.k8s.io", version: "v1beta1", kind: "Ingress"},
+			{group: "networking.k8s.io", version: "v1", kind: "Ingress"},
+		},
+		{
+			{group: "batch", version: "v1beta1", kind: "CronJob", deprecated: true},
+			{group: "batch", version: "v1", kind: "CronJob"},
+			{group: "policy", version: "v1beta1", kind: "PodSecurityPolicy", deprecated: true},
+			{group: "networking.k8s.io", version: "v1beta1", kind: "Ingress", deprecated: true},
+			{group: "networking.k8s.io", version: "v1", kind: "Ingress"},
+		},
+		{
+			{group: "batch", version: "v1beta1", kind: "CronJob", deprecated: true},
+			{group: "batch", version: "v1", kind: "CronJob"},
+			{group: "policy", version: "v1beta1", kind: "PodSecurityPolicy", deprecated: true},
+			{group: "networking.k8s.io", version: "v1", kind: "Ingress"},
+		},
+		{
+			{group: "batch", version: "v1", kind: "CronJob"},
+			{group: "networking.k8s.io", version: "v1", kind: "Ingress"},
+		},
+	}
+	return newApiLifecycleTimeline(releases, apis)
+}
+
+func TestApiLifecycleTimeline_Explain(t *testing.T) {
+	timeline := testApiLifecycleTimeline()
+	tests := []struct {
+		name  string
+		query string
+		want  []ApiLifecycle
+	}{
+		{
+			name:  "kind",
+			query: "cronjob",
+			want: []ApiLifecycle{
+				{Group: "batch", Version: "v1beta1", Kind: "CronJob", IntroducedInRelease: "1.20", DeprecatedInRelease: "1.21", RemovedInRelease: "1.25", ReplacementAPIVersion: "batch/v1"},
+				{Group: "batch", Version: "v1", Kind: "CronJob", IntroducedInRelease: "1.21"},
+			},
+		},
+		{
+			name:  "apiVersion and kind",
+			query: "networking.k8s.io/v1beta1/Ingress",
+			want: []ApiLifecycle{
+				{Group: "networking.k8s.io", Version: "v1beta1", Kind: "Ingress", IntroducedInRelease: "1.20", DeprecatedInRelease: "1.21", RemovedInRelease: "1.22", ReplacementAPIVersion: "networking.k8s.io/v1"},
+			},
+		},
+		{
+			name:  "removed without replacement",
+			query: "PodSecurityPolicy",
+			want: []ApiLifecycle{
+				{Group: "policy", Version: "v1beta1", Kind: "PodSecurityPolicy", IntroducedInRelease: "1.20", DeprecatedInRelease: "1.21", RemovedInRelease: "1.25"},
+			},
+		},
+		{
+			name:  "unknown kind",
+			query: "apps/v1/Deployment",
+			want:  []ApiLifecycle{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, timeline.Explain(tt.query))
+		})
+	}
+}
+
+func TestApiLifecycleTimeline_Annotate(t *testing.T) {
+	timeline := testApiLifecycleTimeline()
+	tests := []struct {
+		name           string
+		result         ValidationResult
+		wantDeprecated string
+		wantRemoved    string
+	}{
+		{name: "removed", result: ValidationResult{Kind: "CronJob", APIVersion: "batch/v1beta1", Deleted: true}, wantDeprecated: "1.21", wantRemoved: "1.25"},
+		{name: "served", result: ValidationResult{Kind: "CronJob", APIVersion: "batch/v1"}},
+		{name: "unknown", result: ValidationResult{Kind: "Deployment", APIVersion: "extensions/v1beta1", Deleted: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeline.Annotate(&tt.result)
+			assert.Equal(t, tt.wantDeprecated, tt.result.DeprecatedInRelease)
+			assert.Equal(t, tt.wantRemoved, tt.result.RemovedInRelease)
+		})
+	}
+}
+
+func TestKubeCheckerApiLifecycleTimeline(t *testing.T) {
+	specFile := filepath.Join(t.TempDir(), "swagger.json")
+	assert.NoError(t, ioutil.WriteFile(specFile, []byte(testSwaggerSpec), 0644))
+	kubeC := NewKubeCheckerImpl()
+	assert.NoError(t, kubeC.LoadFromPath("1.22", specFile, false))
+	timeline, err := kubeC.ApiLifecycleTimeline([]string{"v1.22.3"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.22"}, timeline.Releases)
+	assert.NotEmpty(t, timeline.Lifecycles)
+	for _, lifecycle := range timeline.Lifecycles {
+		assert.Equal(t, "1.22", lifecycle.IntroducedInRelease)
+		assert.Empty(t, lifecycle.RemovedInRelease)
+	}
+}
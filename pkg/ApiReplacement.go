@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"k8s.io/apimachinery/pkg/version"
+	"strings"
+)
+
+// apiGroupMoves maps group/kind, kind in lower case, to groups the kind moved to, oldest first. Kinds are migrated
+// within their own group unless they are listed here, kinds mapped to no group are removed without replacement.
+var apiGroupMoves = map[string][]string{
+	"extensions/daemonset":         {"apps"},
+	"extensions/deployment":        {"apps"},
+	"extensions/replicaset":        {"apps"},
+	"extensions/ingress":           {"networking.k8s.io"},
+	"extensions/networkpolicy":     {"networking.k8s.io"},
+	"extensions/podsecuritypolicy": {"policy"},
+	// PodSecurityPolicy is removed in 1.25 in favour of Pod Security Admission which is not an api
+	"policy/podsecuritypolicy": {},
+}
+
+// apiGroupLineage returns groups which kind of group can be migrated to, successors first and group itself last
+func apiGroupLineage(group, kind string) []string {
+	lineage := []string{group}
+	for moves := apiGroupMoves[strings.ToLower(group+"/"+kind)]; len(moves) > 0; {
+		next := moves[len(moves)-1]
+		lineage = append([]string{next}, lineage...)
+		moves = apiGroupMoves[strings.ToLower(next+"/"+kind)]
+	}
+	return lineage
+}
+
+// replacementKindInfo returns kind info of kind of group to migrate to. Groups of the lineage of group are tried
+// successors first, within a group the version preferred by the api server is picked if it is known, otherwise the
+// storage version of custom resources or the highest priority version which is not deprecated, as the api server
+// orders them. Only served versions are considered so that the replacement is a real migration target.
+func (ks *kubeSpec) replacementKindInfo(group, kind string) *KindInfo {
+	kindInfos := ks.kindInfoMap[strings.ToLower(kind)]
+	for _, g := range apiGroupLineage(group, kind) {
+		var served []*KindInfo
+		for _, ki := range kindInfos {
+			if ki.Group == g && len(ki.RestPath) > 0 {
+				served = append(served, ki)
+			}
+		}
+		if len(served) == 0 {
+			continue
+		}
+		if preferred, ok := ks.preferredVersions[g]; ok {
+			for _, ki := range served {
+				if ki.Version == preferred {
+					return ki
+				}
+			}
+		}
+		for _, ki := range served {
+			if ki.IsStorage {
+				return ki
+			}
+		}
+		var replacement *KindInfo
+		for _, ki := range served {
+			if replacement == nil || ks.isPreferredOver(ki, replacement) {
+				replacement = ki
+			}
+		}
+		return replacement
+	}
+	return nil
+}
+
+// isPreferredOver tells if lhs is preferred over rhs, versions which are not deprecated are preferred, otherwise GA
+// versions over beta and alpha ones and newer over older
+func (ks *kubeSpec) isPreferredOver(lhs, rhs *KindInfo) bool {
+	lhsDeprecated, rhsDeprecated := ks.isDeprecatedKind(lhs), ks.isDeprecatedKind(rhs)
+	if lhsDeprecated != rhsDeprecated {
+		return rhsDeprecated
+	}
+	return version.CompareKubeAwareVersionStrings(lhs.Version, rhs.Version) > 0
+}
+
+func (ks *kubeSpec) isDeprecatedKind(ki *KindInfo) bool {
+	if ki.Deprecated {
+		return true
+	}
+	scm, err := ks.schemaLookup(ki.ComponentKey)
+	return err == nil && isDeprecatedDescription(scm.Description)
+}
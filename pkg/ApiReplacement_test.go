@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiGroupLineage(t *testing.T) {
+	tests := []struct {
+		group string
+		kind  string
+		want  []string
+	}{
+		{group: "apps", kind: "Deployment", want: []string{"apps"}},
+		{group: "extensions", kind: "Deployment", want: []string{"apps", "extensions"}},
+		{group: "extensions", kind: "Ingress", want: []string{"networking.k8s.io", "extensions"}},
+		{group: "extensions", kind: "PodSecurityPolicy", want: []string{"policy", "extensions"}},
+		{group: "", kind: "Event", want: []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.group+"/"+tt.kind, func(t *testing.T) {
+			assert.Equal(t, tt.want, apiGroupLineage(tt.group, tt.kind))
+		})
+	}
+}
+
+func TestReplacementKindInfo(t *testing.T) {
+	served := func(group, version string) *KindInfo {
+		return &KindInfo{Group: group, Version: version, RestPath: "/" + groupVersion(group, version),
+			ComponentKey: groupVersion(group, version)}
+	}
+	kindInfoMap := map[string][]*KindInfo{
+		"event":                   {served("", "v1"), served("events.k8s.io", "v1beta1"), served("events.k8s.io", "v1")},
+		"deployment":              {served("extensions", "v1beta1"), served("apps", "v1beta2"), served("apps", "v1")},
+		"horizontalpodautoscaler": {served("autoscaling", "v1"), served("autoscaling", "v2beta2")},
+		"podsecuritypolicy":       {served("extensions", "v1beta1"), {Group: "policy", Version: "v1beta1"}},
+		"widget": {served("example.com", "v1beta1"), served("example.com", "v1"),
+			{Group: "example.com", Version: "v2", ComponentKey: "example.com/v2"}},
+	}
+	kindInfoMap["widget"][0].IsStorage = true
+
+	tests := []struct {
+		name              string
+		group             string
+		kind              string
+		preferredVersions map[string]string
+		want              string
+	}{
+		{name: "core group is not mixed with other groups", group: "", kind: "Event", want: "v1"},
+		{name: "latest version of the group", group: "events.k8s.io", kind: "Event", want: "events.k8s.io/v1"},
+		{name: "group the kind moved to", group: "extensions", kind: "Deployment", want: "apps/v1"},
+		{name: "ga version over beta of newer major version", group: "autoscaling", kind: "HorizontalPodAutoscaler", want: "autoscaling/v1"},
+		{name: "version preferred by the api server", group: "autoscaling", kind: "HorizontalPodAutoscaler",
+			preferredVersions: map[string]string{"autoscaling": "v2beta2"}, want: "autoscaling/v2beta2"},
+		{name: "storage version of custom resources", group: "example.com", kind: "Widget", want: "example.com/v1beta1"},
+		{name: "versions which are not served are skipped", group: "extensions", kind: "PodSecurityPolicy", want: "extensions/v1beta1"},
+		{name: "unknown kind", group: "apps", kind: "Gadget"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks := &kubeSpec{T: &openapi3.T{Components: openapi3.Components{Schemas: openapi3.Schemas{}}},
+				kindInfoMap: kindInfoMap, preferredVersions: tt.preferredVersions}
+			got := ""
+			if ki := ks.replacementKindInfo(tt.group, tt.kind); ki != nil {
+				got = groupVersion(ki.Group, ki.Version)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// behaviourCheckKey identifies migration of kind from apiVersion From to apiVersion To
+type behaviourCheckKey struct {
+	Kind string
+	From string
+	To   string
+}
+
+// behaviourCheck returns changes in behaviour of object once it is migrated, which schemas of both apiVersions do
+// not tell as the object is valid in both
+type behaviourCheck func(object map[string]interface{}) []*SchemaError
+
+// behaviourChecks is the built-in pack of checks of migrations which are schema compatible but change behaviour
+var behaviourChecks = map[behaviourCheckKey][]behaviourCheck{
+	{Kind: "PodDisruptionBudget", From: "policy/v1beta1", To: "policy/v1"}: {checkEmptyPodDisruptionBudgetSelector},
+
+	{Kind: "HorizontalPodAutoscaler", From: "autoscaling/v1", To: "autoscaling/v2"}:           {checkTargetCPUUtilization},
+	{Kind: "HorizontalPodAutoscaler", From: "autoscaling/v1", To: "autoscaling/v2beta2"}:      {checkTargetCPUUtilization},
+	{Kind: "HorizontalPodAutoscaler", From: "autoscaling/v2beta1", To: "autoscaling/v2"}:      {checkMetricTargets},
+	{Kind: "HorizontalPodAutoscaler", From: "autoscaling/v2beta1", To: "autoscaling/v2beta2"}: {checkMetricTargets},
+
+	{Kind: "Ingress", From: "extensions/v1beta1", To: "networking.k8s.io/v1"}:        {checkIngressPathType, checkIngressBackends},
+	{Kind: "Ingress", From: "networking.k8s.io/v1beta1", To: "networking.k8s.io/v1"}: {checkIngressPathType, checkIngressBackends},
+
+	{Kind: "CronJob", From: "batch/v1beta1", To: "batch/v1"}:  {checkCronJobTimeZone},
+	{Kind: "CronJob", From: "batch/v2alpha1", To: "batch/v1"}: {checkCronJobTimeZone},
+}
+
+// behaviourChanges returns changes in behaviour of object of kind once it is migrated from apiVersion from to
+// apiVersion to
+func behaviourChanges(object map[string]interface{}, kind, from, to string) []*SchemaError {
+	if len(to) == 0 || from == to {
+		return nil
+	}
+	var changes []*SchemaError
+	for _, check := range behaviourChecks[behaviourCheckKey{Kind: kind, From: from, To: to}] {
+		changes = append(changes, check(object)...)
+	}
+	return changes
+}
+
+func behaviourChange(id, severity, reason, remediation string, path ...string) *SchemaError {
+	se := &SchemaError{Reason: reason, RuleID: id, Severity: severity, Remediation: remediation, reversePath: reversed(path)}
+	if len(path) > 0 {
+		se.SchemaField = path[len(path)-1]
+	}
+	return se
+}
+
+// checkEmptyPodDisruptionBudgetSelector reports empty selectors which select no pod in policy/v1beta1 but every pod
+// of the namespace in policy/v1, null selectors select no pod in both
+func checkEmptyPodDisruptionBudgetSelector(object map[string]interface{}) []*SchemaError {
+	selector, found, err := unstructured.NestedMap(object, "spec", "selector")
+	if !found || err != nil || selector == nil {
+		return nil
+	}
+	matchLabels, _, _ := unstructured.NestedMap(selector, "matchLabels")
+	matchExpressions, _, _ := unstructured.NestedSlice(selector, "matchExpressions")
+	if len(matchLabels) > 0 || len(matchExpressions) > 0 {
+		return nil
+	}
+	return []*SchemaError{behaviourChange("pdb-empty-selector", SeverityError,
+		"empty selector selects no pods in policy/v1beta1 but every pod of the namespace in policy/v1",
+		"set matchLabels of the pods to protect, or remove the PodDisruptionBudget if it is not meant to protect any pod",
+		"spec", "selector")}
+}
+
+// checkTargetCPUUtilization reports targetCPUUtilizationPercentage which is a cpu resource metric in v2
+func checkTargetCPUUtilization(object map[string]interface{}) []*SchemaError {
+	target, found, _ := unstructured.NestedFieldNoCopy(object, "spec", "targetCPUUtilizationPercentage")
+	if !found {
+		return nil
+	}
+	return []*SchemaError{behaviourChange("hpa-target-cpu-utilization", SeverityWarning,
+		"targetCPUUtilizationPercentage is replaced by a Resource metric of cpu in spec.metrics",
+		fmt.Sprintf("set spec.metrics to [{type: Resource, resource: {name: cpu, target: {type: Utilization, averageUtilization: %v}}}]", target),
+		"spec", "targetCPUUtilizationPercentage")}
+}
+
+// metricSources maps types of metrics to fields holding their source
+var metricSources = map[string]string{
+	"Object":            "object",
+	"Pods":              "pods",
+	"Resource":          "resource",
+	"ContainerResource": "containerResource",
+	"External":          "external",
+}
+
+// metricFieldMoves maps fields of sources of metrics of autoscaling/v2beta1 to where they are in v2, in the order
+// they are reported
+var metricFieldMoves = []struct {
+	from string
+	to   string
+}{
+	{from: "metricName", to: "metric.name"},
+	{from: "metricSelector", to: "metric.selector"},
+	{from: "targetAverageUtilization", to: "target.averageUtilization"},
+	{from: "targetAverageValue", to: "target.averageValue"},
+	{from: "targetValue", to: "target.value"},
+}
+
+// checkMetricTargets reports names, selectors and targets of metrics which are nested in metric and target in v2
+func checkMetricTargets(object map[string]interface{}) []*SchemaError {
+	metrics, _, _ := unstructured.NestedSlice(object, "spec", "metrics")
+	var changes []*SchemaError
+	for i, m := range metrics {
+		metric, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		metricType, _ := metric["type"].(string)
+		source, ok := metric[metricSources[metricType]].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, move := range metricFieldMoves {
+			if _, ok := source[move.from]; !ok {
+				continue
+			}
+			id := "hpa-metric-target"
+			if strings.HasPrefix(move.to, "metric.") {
+				id = "hpa-metric-identifier"
+			}
+			changes = append(changes, behaviourChange(id, SeverityWarning,
+				fmt.Sprintf("%s is replaced by %s", move.from, move.to),
+				fmt.Sprintf("move %s to %s of the metric, targets need target.type as well", move.from, move.to),
+				"spec", "metrics", strconv.Itoa(i), metricSources[metricType], move.from))
+		}
+	}
+	return changes
+}
+
+// checkIngressPathType reports paths without pathType which defaults to ImplementationSpecific in v1beta1 but is
+// required in v1, where Prefix and Exact match differently from regular expressions of most controllers
+func checkIngressPathType(object map[string]interface{}) []*SchemaError {
+	rules, _, _ := unstructured.NestedSlice(object, "spec", "rules")
+	var changes []*SchemaError
+	for i, r := range rules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		paths, _, _ := unstructured.NestedSlice(rule, "http", "paths")
+		for j, p := range paths {
+			path, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if _, ok := path["pathType"]; ok {
+				continue
+			}
+			changes = append(changes, behaviourChange("ingress-path-type", SeverityError,
+				"pathType is required in networking.k8s.io/v1, paths without it are ImplementationSpecific in v1beta1",
+				"set pathType to ImplementationSpecific to keep current matching, or to Prefix or Exact if the path is not a regular expression",
+				"spec", "rules", strconv.Itoa(i), "http", "paths", strconv.Itoa(j), "pathType"))
+		}
+	}
+	return changes
+}
+
+// checkIngressBackends reports backends referring to services by serviceName and servicePort, replaced by service in
+// v1, and spec.backend which is renamed to spec.defaultBackend
+func checkIngressBackends(object map[string]interface{}) []*SchemaError {
+	var changes []*SchemaError
+	if _, found, _ := unstructured.NestedFieldNoCopy(object, "spec", "backend"); found {
+		changes = append(changes, behaviourChange("ingress-default-backend", SeverityWarning,
+			"spec.backend is renamed to spec.defaultBackend", "rename spec.backend to spec.defaultBackend", "spec", "backend"))
+	}
+	rules, _, _ := unstructured.NestedSlice(object, "spec", "rules")
+	for i, r := range rules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		paths, _, _ := unstructured.NestedSlice(rule, "http", "paths")
+		for j, p := range paths {
+			path, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if _, found, _ := unstructured.NestedFieldNoCopy(path, "backend", "serviceName"); found {
+				changes = append(changes, behaviourChange("ingress-service-backend", SeverityWarning,
+					"serviceName and servicePort are replaced by service.name and service.port.number or service.port.name",
+					"move serviceName to service.name and servicePort to service.port.number, or service.port.name if it is a name",
+					"spec", "rules", strconv.Itoa(i), "http", "paths", strconv.Itoa(j), "backend"))
+			}
+		}
+	}
+	return changes
+}
+
+// checkCronJobTimeZone reports time zones set in schedule, which batch/v1 rejects in favour of spec.timeZone
+func checkCronJobTimeZone(object map[string]interface{}) []*SchemaError {
+	schedule, _, _ := unstructured.NestedString(object, "spec", "schedule")
+	if !strings.HasPrefix(schedule, "TZ=") && !strings.HasPrefix(schedule, "CRON_TZ=") {
+		return nil
+	}
+	zone := strings.SplitN(strings.SplitN(schedule, "=", 2)[1], " ", 2)[0]
+	return []*SchemaError{behaviourChange("cronjob-schedule-time-zone", SeverityError,
+		"time zones in schedule are not supported in batch/v1, spec.timeZone is to be used instead",
+		fmt.Sprintf("remove the time zone from schedule and set spec.timeZone to %s", zone),
+		"spec", "schedule")}
+}
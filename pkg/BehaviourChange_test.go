@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func TestBehaviourChanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		kind      string
+		from      string
+		to        string
+		object    string
+		wantPaths []string
+		wantIDs   []string
+	}{
+		{name: "pdb with empty selector", kind: "PodDisruptionBudget", from: "policy/v1beta1", to: "policy/v1",
+			object:    "spec: {minAvailable: 1, selector: {}}",
+			wantPaths: []string{"spec/selector"}, wantIDs: []string{"pdb-empty-selector"}},
+		{name: "pdb with labels", kind: "PodDisruptionBudget", from: "policy/v1beta1", to: "policy/v1",
+			object: "spec: {minAvailable: 1, selector: {matchLabels: {app: a}}}"},
+		{name: "pdb with null selector", kind: "PodDisruptionBudget", from: "policy/v1beta1", to: "policy/v1",
+			object: "spec: {minAvailable: 1}"},
+		{name: "hpa with cpu target", kind: "HorizontalPodAutoscaler", from: "autoscaling/v1", to: "autoscaling/v2",
+			object:    "spec: {maxReplicas: 3, targetCPUUtilizationPercentage: 80}",
+			wantPaths: []string{"spec/targetCPUUtilizationPercentage"}, wantIDs: []string{"hpa-target-cpu-utilization"}},
+		{name: "hpa with v2beta1 metrics", kind: "HorizontalPodAutoscaler", from: "autoscaling/v2beta1", to: "autoscaling/v2",
+			object: `
+spec:
+  metrics:
+  - type: Resource
+    resource: {name: cpu, targetAverageUtilization: 80}
+  - type: External
+    external: {metricName: queue, targetValue: 10}`,
+			wantPaths: []string{"spec/metrics/0/resource/targetAverageUtilization", "spec/metrics/1/external/metricName", "spec/metrics/1/external/targetValue"},
+			wantIDs:   []string{"hpa-metric-target", "hpa-metric-identifier", "hpa-metric-target"}},
+		{name: "ingress without path type", kind: "Ingress", from: "extensions/v1beta1", to: "networking.k8s.io/v1",
+			object: `
+spec:
+  backend: {serviceName: default, servicePort: 80}
+  rules:
+  - http:
+      paths:
+      - path: /a
+        pathType: Prefix
+        backend: {service: {name: a, port: {number: 80}}}
+      - path: /b
+        backend: {serviceName: b, servicePort: 80}`,
+			wantPaths: []string{"spec/rules/0/http/paths/1/pathType", "spec/backend", "spec/rules/0/http/paths/1/backend"},
+			wantIDs:   []string{"ingress-path-type", "ingress-default-backend", "ingress-service-backend"}},
+		{name: "cronjob with time zone in schedule", kind: "CronJob", from: "batch/v1beta1", to: "batch/v1",
+			object:    "spec: {schedule: 'CRON_TZ=Europe/Berlin 0 1 * * *'}",
+			wantPaths: []string{"spec/schedule"}, wantIDs: []string{"cronjob-schedule-time-zone"}},
+		{name: "cronjob with TZ in schedule", kind: "CronJob", from: "batch/v2alpha1", to: "batch/v1",
+			object:    "spec: {schedule: 'TZ=Etc/UTC 0 1 * * *'}",
+			wantPaths: []string{"spec/schedule"}, wantIDs: []string{"cronjob-schedule-time-zone"}},
+		{name: "cronjob without time zone", kind: "CronJob", from: "batch/v1beta1", to: "batch/v1",
+			object: "spec: {schedule: '0 1 * * *'}"},
+		{name: "cronjob with time zone", kind: "CronJob", from: "batch/v1beta1", to: "batch/v1",
+			object: "spec: {schedule: '0 1 * * *', timeZone: Etc/UTC}"},
+		{name: "same api version", kind: "CronJob", from: "batch/v1", to: "batch/v1",
+			object: "spec: {schedule: '0 1 * * *'}"},
+		{name: "migration without checks", kind: "Deployment", from: "apps/v1beta2", to: "apps/v1",
+			object: "spec: {replicas: 1}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var object map[string]interface{}
+			assert.NoError(t, yaml.Unmarshal([]byte(tt.object), &object))
+			var paths, ids []string
+			for _, change := range behaviourChanges(object, tt.kind, tt.from, tt.to) {
+				paths = append(paths, strings.Join(change.JSONPointer(), "/"))
+				ids = append(ids, change.RuleID)
+			}
+			assert.Equal(t, tt.wantPaths, paths)
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
@@ -2,13 +2,17 @@ package pkg
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"github.com/devtron-labs/silver-surfer/pkg/errors"
+	kLog "github.com/devtron-labs/silver-surfer/pkg/log"
 	"os"
 	"os/user"
 	"path/filepath"
 	"strings"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -112,8 +116,133 @@ func (c *Cluster) ServerVersion() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("%s.%s", info.Major, strings.Trim(info.Minor, "+")), nil
+	// minor version carries a + on managed clusters eg 27+, some distributions leave major and minor empty
+	version, err := ResolveReleaseVersion(fmt.Sprintf("%s.%s", info.Major, info.Minor), nil)
+	if err != nil {
+		return ResolveReleaseVersion(info.GitVersion, nil)
+	}
+	return version, nil
+}
+
+// PreferredVersions returns versions of api groups preferred by the api server, the core group is keyed by an empty
+// string
+func (c *Cluster) PreferredVersions() (map[string]string, error) {
+	groups, err := c.disco.ServerGroups()
+	if err != nil {
+		return nil, err
+	}
+	preferredVersions := make(map[string]string, len(groups.Groups))
+	for _, group := range groups.Groups {
+		preferredVersions[group.Name] = group.PreferredVersion.Version
+	}
+	return preferredVersions, nil
+}
+
+// OpenApiSpec returns openapi spec served by the api server converted to openapi 3, it covers aggregated apis and
+// vendor specific groups as well. Schemas of per group-version /openapi/v3 documents complement /openapi/v2 since
+// the latter omits schemas, of CRDs for instance, which are not expressible in swagger 2.0
+func (c *Cluster) OpenApiSpec() ([]byte, error) {
+	data, err := c.disco.RESTClient().Get().AbsPath("/openapi/v2").SetHeader("Accept", "application/json").
+		Do(context.Background()).Raw()
+	if err != nil {
+		return nil, err
+	}
+	converted, err := ConvertOpenApi2(data)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := c.disco.OpenAPIV3().Paths()
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			kLog.Warn(fmt.Sprintf("unable to fetch openapi v3 paths, using openapi v2 only: %v", err))
+		}
+		return converted, nil
+	}
+	spec := map[string]interface{}{}
+	if err := json.Unmarshal(converted, &spec); err != nil {
+		return nil, err
+	}
+	for path, groupVersion := range paths {
+		data, err := groupVersion.Schema("application/json")
+		if err != nil {
+			kLog.Warn(fmt.Sprintf("unable to fetch openapi v3 spec of %s: %v", path, err))
+			continue
+		}
+		groupSpec := map[string]interface{}{}
+		if err := json.Unmarshal(data, &groupSpec); err != nil {
+			kLog.Warn(fmt.Sprintf("invalid openapi v3 spec of %s: %v", path, err))
+			continue
+		}
+		mergeOpenApi3(spec, groupSpec)
+	}
+	return json.Marshal(spec)
 }
+
+// SchemaSource returns source serving openapi spec of the api server as spec of releaseVersion
+func (c *Cluster) SchemaSource(releaseVersion string) SchemaSource {
+	return &clusterSchemaSource{cluster: c, releaseVersion: releaseVersion}
+}
+
+type clusterSchemaSource struct {
+	cluster        *Cluster
+	releaseVersion string
+}
+
+func (s *clusterSchemaSource) Name() string {
+	return s.cluster.restConfig.Host
+}
+
+// FetchOpenApi3 returns per group-version /openapi/v3 documents served by the api server
+func (s *clusterSchemaSource) FetchOpenApi3(releaseVersion string) ([][]byte, error) {
+	if releaseVersion != s.releaseVersion {
+		return nil, errors.ErrOpenApiSpecNotFound
+	}
+	paths, err := s.cluster.disco.OpenAPIV3().Paths()
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errors.ErrOpenApiSpecNotFound
+		}
+		return nil, err
+	}
+	var docs [][]byte
+	for path, groupVersion := range paths {
+		if !isGroupVersionPath(path) {
+			continue
+		}
+		data, err := groupVersion.Schema("application/json")
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch openapi v3 spec of %s: %w", path, err)
+		}
+		docs = append(docs, data)
+	}
+	return docs, nil
+}
+
+func (s *clusterSchemaSource) Fetch(releaseVersion string) ([]byte, string, error) {
+	if releaseVersion != s.releaseVersion {
+		return nil, "", errors.ErrOpenApiSpecNotFound
+	}
+	data, err := s.cluster.OpenApiSpec()
+	if err != nil {
+		return nil, "", err
+	}
+	return data, SpecFormatOpenApi3, nil
+}
+
+// FetchCustomResourceDefinitions lists CustomResourceDefinitions installed in the cluster
+func (c *Cluster) FetchCustomResourceDefinitions() ([]map[string]interface{}, error) {
+	gvr := schema.GroupVersionResource{Group: crdGroup, Version: "v1", Resource: "customresourcedefinitions"}
+	objList, err := c.clientset.Resource(gvr).List(context.Background(), v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var crds []map[string]interface{}
+	for _, obj := range objList.Items {
+		crds = append(crds, obj.Object)
+	}
+	return crds, nil
+}
+
 func (c *Cluster) FetchK8sObjects(gvks []schema.GroupVersionKind, conf *Config) []unstructured.Unstructured {
 	var resources []schema.GroupVersionResource
 	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(c.disco))
@@ -1,7 +1,12 @@
 package pkg
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
 )
 
 func TestCluster_ServerVersion(t *testing.T) {
@@ -32,4 +37,72 @@ func TestCluster_ServerVersion(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+// testWidgetOpenApi3Spec is /openapi/v3 document of an aggregated group which is missing in /openapi/v2
+const testWidgetOpenApi3Spec = `
+{
+  "openapi": "3.0.0",
+  "info": {"title": "Kubernetes", "version": "unversioned"},
+  "paths": {
+    "/apis/example.com/v1/widgets": {
+      "post": {
+        "operationId": "createExampleComV1Widget",
+        "x-kubernetes-group-version-kind": {"group": "example.com", "kind": "Widget", "version": "v1"},
+        "responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/com.example.v1.Widget"}}}}}
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "com.example.v1.Widget": {
+        "type": "object",
+        "properties": {"apiVersion": {"type": "string"}, "kind": {"type": "string"}, "size": {"type": "integer"}},
+        "x-kubernetes-group-version-kind": [{"group": "example.com", "kind": "Widget", "version": "v1"}]
+      }
+    }
+  }
+}`
+
+func TestCluster_OpenApiSpec(t *testing.T) {
+	tests := []struct {
+		name      string
+		openApiV3 bool
+		want      map[string]bool
+	}{
+		{
+			name:      "openapi v2 only",
+			openApiV3: false,
+			want:      map[string]bool{"apps/v1/Deployment": true, "example.com/v1/Widget": false},
+		},
+		{
+			name:      "openapi v3 documents complement openapi v2",
+			openApiV3: true,
+			want:      map[string]bool{"apps/v1/Deployment": true, "example.com/v1/Widget": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/openapi/v2":
+					w.Write([]byte(testSwaggerSpec))
+				case r.URL.Path == "/openapi/v3" && tt.openApiV3:
+					w.Write([]byte(`{"paths": {"apis/example.com/v1": {"serverRelativeURL": "/openapi/v3/apis/example.com/v1"}}}`))
+				case r.URL.Path == "/openapi/v3/apis/example.com/v1" && tt.openApiV3:
+					w.Write([]byte(testWidgetOpenApi3Spec))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+			c := NewClusterFromEnvOrConfig(&rest.Config{Host: server.URL})
+			kc := NewKubeCheckerImpl()
+			assert.NoError(t, kc.LoadFromSource("1.29", c.SchemaSource("1.29"), false))
+			assert.Equal(t, tt.want["apps/v1/Deployment"], kc.IsApiVersionSupported("1.29", "apps/v1", "Deployment"))
+			assert.Equal(t, tt.want["example.com/v1/Widget"], kc.IsApiVersionSupported("1.29", "example.com/v1", "Widget"))
+			_, _, err := c.SchemaSource("1.29").Fetch("1.22")
+			assert.Error(t, err)
+		})
+	}
+}
@@ -22,6 +22,7 @@ package pkg
 import (
 	"fmt"
 	"github.com/spf13/cobra"
+	"time"
 )
 
 // A Config object contains various configuration data for kubedd
@@ -41,22 +42,66 @@ type Config struct {
 	SourceKubernetesVersion string
 
 	// TargetSchemaLocation is the base URL of target kubernetes version.
-	// It can be either a remote location or a local directory
+	// It can be either a remote location, a local file or a schema bundle
+	// i.e; directory or tar.gz holding specs of many kubernetes versions
 	TargetSchemaLocation string
 
 	// SourceSchemaLocation is the base URL of source kubernetes versions.
-	// It can be either a remote location or a local directory
+	// It can be either a remote location, a local file or a schema bundle
+	// i.e; directory or tar.gz holding specs of many kubernetes versions
 	SourceSchemaLocation string
 
-	// AdditionalSchemaLocations is a list of alternative base URLs from
-	// which to search for schemas, given that the desired schema was not
-	// found at TargetSchemaLocation
+	// SchemaLocation is the location from which openapi specs of kubernetes
+	// versions are loaded, defaults to the upstream kubernetes repository.
+	// It can be a url template in which %s is replaced by the version, base
+	// URL of a kubernetes repository mirror, a file path template, a
+	// directory of specs or a schema bundle
+	SchemaLocation string
+
+	// AdditionalSchemaLocations is a list of alternative locations, in the
+	// same forms as SchemaLocation, which are tried in order given that the
+	// desired schema was not found at SchemaLocation
 	AdditionalSchemaLocations []string
 
+	// OpenApiV3Versions are kubernetes versions loaded from per group-version
+	// openapi 3 documents, i.e; api/openapi-spec/v3, instead of swagger.json.
+	// `*` selects every version
+	OpenApiV3Versions []string
+
+	// SchemaHeaders are extra HTTP headers, in `Name: value` form, sent
+	// while downloading openapi specs from remote schema locations
+	SchemaHeaders []string
+
+	// SchemaBearerToken is sent as bearer token while downloading openapi
+	// specs from remote schema locations
+	SchemaBearerToken string
+
+	// SchemaCAFile is the path of a PEM encoded CA bundle trusted while
+	// downloading openapi specs from remote schema locations
+	SchemaCAFile string
+
+	// UpgradePath is a range of kubernetes versions, <source>..<target>, whose
+	// every minor release is validated against as kubernetes is upgraded one
+	// minor release at a time
+	UpgradePath string
+
+	// ApiLifecycles tells kubedd whether to find releases in which deprecated
+	// and removed apiVersions are deprecated and removed, specs of every
+	// release from source to target version are loaded for it
+	ApiLifecycles bool
+
 	// Strict tells kubedd whether to prohibit properties not in
 	// the schema. The API allows them, but kubectl does not
 	Strict bool
 
+	// DeprecationRules is the path of a YAML file of deprecation rules which
+	// override rules of the same id shipped with kubedd
+	DeprecationRules string
+
+	// RulesDir is a directory of YAML files of custom rules, CEL expressions
+	// which selected kubernetes objects must pass
+	RulesDir string
+
 	// IgnoreMissingSchemas tells kubedd whether to skip validation
 	// for resource definitions without an available schema
 	IgnoreMissingSchemas bool
@@ -80,6 +125,10 @@ type Config struct {
 	// when retrieving schema content over HTTPS
 	InsecureSkipTLSVerify bool
 
+	// SchemaInsecureSkipTLSVerify controls whether to skip TLS certificate validation
+	// of schema locations only
+	SchemaInsecureSkipTLSVerify bool
+
 	// IgnoreKeysFromDeprecation is the list of keys to be skipped for depreciation check
 	IgnoreKeysFromDeprecation []string
 
@@ -100,6 +149,22 @@ type Config struct {
 
 	// IgnoreNullErrors is the flag to ignore null value errors
 	IgnoreNullErrors bool
+
+	// CustomResourceDefinitions are CRDs, found across all input files, whose
+	// schemas are used to validate custom resources
+	CustomResourceDefinitions []map[string]interface{}
+
+	// CacheDir is the directory in which downloaded openapi specs and their
+	// converted form are persisted, caching is disabled if it is empty
+	CacheDir string
+
+	// CacheTTL is the duration after which cached openapi specs are
+	// revalidated against the location they were downloaded from
+	CacheTTL time.Duration
+
+	// RefreshSchemas tells kubedd to ignore cached openapi specs and
+	// download them again
+	RefreshSchemas bool
 }
 
 // NewDefaultConfig creates a Config with default values
@@ -111,23 +176,45 @@ func NewDefaultConfig() *Config {
 	}
 }
 
+// AddSchemaClientFlags adds flags of config used while downloading openapi specs to cmd
+func AddSchemaClientFlags(cmd *cobra.Command, config *Config) *cobra.Command {
+	cmd.Flags().StringArrayVarP(&config.SchemaHeaders, "schema-header", "", []string{}, "HTTP header, in 'Name: value' form, sent while downloading openapi specs. Can be repeated")
+	cmd.Flags().StringVarP(&config.SchemaBearerToken, "schema-bearer-token", "", "", "Bearer token sent while downloading openapi specs")
+	cmd.Flags().StringVarP(&config.SchemaCAFile, "schema-ca-file", "", "", "Path of a PEM encoded CA bundle trusted while downloading openapi specs")
+	cmd.Flags().BoolVar(&config.SchemaInsecureSkipTLSVerify, "schema-insecure-skip-tls-verify", false, "If true, certificates of schema locations will not be checked for validity while downloading openapi specs. This will make these HTTPS connections insecure")
+	cmd.Flags().BoolVar(&config.InsecureSkipTLSVerify, "insecure-skip-tls-verify", false, "If true, the server's certificate will not be checked for validity. This will make your HTTPS connections insecure")
+
+	return cmd
+}
+
 // AddKubeaddFlags adds the default flags for kubedd to cmd
 func AddKubeaddFlags(cmd *cobra.Command, config *Config) *cobra.Command {
-	//cmd.Flags().StringVarP(&config.FileName, "filename", "f", "stdin", "Filename to be displayed when testing manifests read from stdin")
-	cmd.Flags().StringVarP(&config.TargetSchemaLocation, "target-schema-location", "", "", "TargetSchemaLocation is the file path of kubernetes version of the target cluster for these manifests. Use this in air-gapped environment where it internet access is unavailable.")
-	cmd.Flags().StringVarP(&config.SourceSchemaLocation, "source-schema-location", "", "", "SourceSchemaLocation is the file path of kubernetes versions of the cluster on which manifests are deployed. Use this in air-gapped environment where it internet access is unavailable.")
-	cmd.Flags().StringVarP(&config.TargetKubernetesVersion, "target-kubernetes-version", "", "1.22", "Version of Kubernetes to migrate to eg 1.22, 1.21, 1.12")
+	cmd.Flags().StringVarP(&config.TargetSchemaLocation, "target-schema-location", "", "", "TargetSchemaLocation is the file path of kubernetes version of the target cluster for these manifests, or a schema bundle (directory or tar.gz created by `schemas pack`) holding many kubernetes versions. Use this in air-gapped environment where it internet access is unavailable.")
+	cmd.Flags().StringVarP(&config.SourceSchemaLocation, "source-schema-location", "", "", "SourceSchemaLocation is the file path of kubernetes versions of the cluster on which manifests are deployed, or a schema bundle (directory or tar.gz created by `schemas pack`) holding many kubernetes versions. Use this in air-gapped environment where it internet access is unavailable.")
+	cmd.Flags().StringVarP(&config.TargetKubernetesVersion, "target-kubernetes-version", "", "1.22", "Version of Kubernetes to migrate to eg 1.22, v1.29.3, latest, latest-1 or master")
 	cmd.Flags().StringVarP(&config.SourceKubernetesVersion, "source-kubernetes-version", "", "", "Version of Kubernetes of the cluster on which kubernetes objects are deployed currently, ignored in case cluster is provided. In case of directory defaults to same as target-kubernetes-version.")
+	cmd.Flags().StringVarP(&config.UpgradePath, "upgrade-path", "", "", "Range of kubernetes versions eg 1.22..1.29, manifests are validated against every minor release in between to find what blocks each step of the upgrade")
+	cmd.Flags().BoolVar(&config.ApiLifecycles, "api-lifecycles", false, "Report releases in which deprecated and removed apiVersions are deprecated and removed, specs of every minor release from source to target version are loaded for it")
 	cmd.Flags().StringVarP(&config.OutputFormat, "output", "o", "", fmt.Sprintf("The format of the output of this script. Options are: %v", "(stdOut | json)"))
 	//cmd.Flags().BoolVar(&config.Quiet, "quiet", false, "Silences any output aside from the direct results")
-	cmd.Flags().BoolVar(&config.InsecureSkipTLSVerify, "insecure-skip-tls-verify", false, "If true, the server's certificate will not be checked for validity. This will make your HTTPS connections insecure")
+	cmd.Flags().StringVarP(&config.SchemaLocation, "schema-location", "", "", "Location of openapi specs of kubernetes versions, either a url template in which %s is replaced by the version, base url of a kubernetes repository mirror, a file path template, a directory of specs or a schema bundle. Defaults to the upstream kubernetes repository")
+	cmd.Flags().StringSliceVarP(&config.AdditionalSchemaLocations, "additional-schema-locations", "", []string{}, "A comma-separated list of locations, in the same forms as schema-location, tried in order if a kubernetes version is not found at schema-location")
+	cmd.Flags().StringSliceVarP(&config.OpenApiV3Versions, "openapi-v3-versions", "", []string{}, "A comma-separated list of kubernetes versions whose specs are loaded from per group-version openapi v3 documents (api/openapi-spec/v3) which retain defaults, nullable, enums and validation rules lost in swagger 2.0. Use * for every version")
+	AddSchemaClientFlags(cmd, config)
 	cmd.Flags().StringSliceVarP(&config.SelectNamespaces, "select-namespaces", "", []string{}, "A comma-separated list of namespaces to be selected, if left empty all namespaces are selected")
 	cmd.Flags().StringSliceVarP(&config.IgnoreNamespaces, "ignore-namespaces", "", []string{"kube-system"}, "A comma-separated list of namespaces to be skipped")
 	cmd.Flags().StringSliceVarP(&config.IgnoreKinds, "ignore-kinds", "", []string{"event", "CustomResourceDefinition"}, "A comma-separated list of kinds to be skipped")
 	cmd.Flags().StringSliceVarP(&config.SelectKinds, "select-kinds", "", []string{}, "A comma-separated list of kinds to be selected, if left empty all kinds are selected")
 	cmd.Flags().StringSliceVarP(&config.IgnoreKeysFromDeprecation, "ignore-keys-for-deprecation", "", []string{"metadata*", "status*"}, "A comma-separated list of keys to be ignored for depreciation check")
 	cmd.Flags().StringSliceVarP(&config.IgnoreKeysFromValidation, "ignore-keys-for-validation", "", []string{"status*", "metadata*"}, "A comma-separated list of keys to be ignored for validation check")
+	cmd.Flags().BoolVar(&config.Strict, "strict", false, "Report fields unknown to the schema, as kubectl apply --validate=strict does. Fields preserved by x-kubernetes-preserve-unknown-fields, RawExtension and map values are not checked")
+	cmd.Flags().StringVarP(&config.DeprecationRules, "deprecation-rules", "", "", "Path of a YAML file of deprecation rules, in the format of pkg/rules/deprecations.yaml, overriding shipped rules of the same id. Rules with disabled: true turn shipped rules off")
+	cmd.Flags().StringVarP(&config.RulesDir, "rules-dir", "", "", "Directory of YAML files of custom rules, each a CEL expression which objects of the selected apiVersions and kinds must pass, along with a severity and a message")
+	cmd.Flags().BoolVar(&config.IgnoreMissingSchemas, "ignore-missing-schemas", false, "Skip resources whose kind has no schema, eg custom resources whose definitions are not available, instead of failing")
 	cmd.Flags().BoolVar(&config.IgnoreNullErrors, "ignore-null-errors", true, "Ignore null value errors")
+	cmd.PersistentFlags().StringVarP(&config.CacheDir, "cache-dir", "", DefaultSchemaCacheDir(), fmt.Sprintf("Directory in which downloaded openapi specs are cached, can also be set via %s. Caching is disabled if empty", CacheDirEnv))
+	cmd.PersistentFlags().DurationVarP(&config.CacheTTL, "cache-ttl", "", DefaultCacheTTL, "Duration after which cached openapi specs are revalidated against the location they were downloaded from")
+	cmd.Flags().BoolVar(&config.RefreshSchemas, "refresh-schemas", false, "Ignore cached openapi specs and download them again")
 
 	return cmd
 }
@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"sort"
+	"strings"
+)
+
+const (
+	crdGroup = "apiextensions.k8s.io"
+	crdKind  = "CustomResourceDefinition"
+)
+
+// preserveUnknownFieldsSchema is used for versions of a CRD which do not declare a schema
+var preserveUnknownFieldsSchema = []byte(`{"type": "object", "x-kubernetes-preserve-unknown-fields": true}`)
+
+// customResourceDefinition holds fields of apiextensions.k8s.io/v1 and v1beta1 CustomResourceDefinition needed to
+// build schema components of custom resources
+type customResourceDefinition struct {
+	Spec struct {
+		Group string `json:"group"`
+		Names struct {
+			Kind   string `json:"kind"`
+			Plural string `json:"plural"`
+		} `json:"names"`
+		// Version and Validation are set by apiextensions.k8s.io/v1beta1 only
+		Version    string         `json:"version"`
+		Validation *crdValidation `json:"validation"`
+		Versions   []crdVersion   `json:"versions"`
+	} `json:"spec"`
+}
+
+type crdVersion struct {
+	Name               string         `json:"name"`
+	Served             bool           `json:"served"`
+	Storage            bool           `json:"storage"`
+	Deprecated         bool           `json:"deprecated"`
+	DeprecationWarning *string        `json:"deprecationWarning"`
+	Schema             *crdValidation `json:"schema"`
+}
+
+type crdValidation struct {
+	OpenAPIV3Schema json.RawMessage `json:"openAPIV3Schema"`
+}
+
+// IsCustomResourceDefinition tells if object is a CustomResourceDefinition
+func IsCustomResourceDefinition(object map[string]interface{}) bool {
+	apiVersion, _ := object["apiVersion"].(string)
+	kind, _ := object["kind"].(string)
+	return kind == crdKind && strings.HasPrefix(apiVersion, crdGroup+"/")
+}
+
+func parseCustomResourceDefinition(object map[string]interface{}) (*customResourceDefinition, error) {
+	if !IsCustomResourceDefinition(object) {
+		return nil, fmt.Errorf("not a %s", crdKind)
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	crd := &customResourceDefinition{}
+	if err := json.Unmarshal(data, crd); err != nil {
+		return nil, err
+	}
+	if len(crd.Spec.Group) == 0 || len(crd.Spec.Names.Kind) == 0 {
+		return nil, fmt.Errorf("%s without group or kind", crdKind)
+	}
+	if len(crd.Spec.Versions) == 0 && len(crd.Spec.Version) > 0 {
+		crd.Spec.Versions = []crdVersion{{Name: crd.Spec.Version, Served: true, Storage: true}}
+	}
+	return crd, nil
+}
+
+// componentKey returns name of schema component of version, following naming of kubernetes components i.e;
+// reversed group followed by version and kind
+func (crd *customResourceDefinition) componentKey(version string) string {
+	parts := strings.Split(crd.Spec.Group, ".")
+	for left, right := 0, len(parts)-1; left < right; left, right = left+1, right-1 {
+		parts[left], parts[right] = parts[right], parts[left]
+	}
+	return fmt.Sprintf("%s.%s.%s", strings.Join(parts, "."), version, crd.Spec.Names.Kind)
+}
+
+// addCustomResourceDefinition adds schema component of every version of crd and registers them as kinds, served
+// versions are given a rest path so that they are considered supported. Kinds registered earlier for the same group
+// are replaced.
+func (ks *kubeSpec) addCustomResourceDefinition(crd *customResourceDefinition) error {
+	kind := strings.ToLower(crd.Spec.Names.Kind)
+	var kindInfos []*KindInfo
+	for _, ki := range ks.kindInfoMap[kind] {
+		if ki.Group != crd.Spec.Group {
+			kindInfos = append(kindInfos, ki)
+		}
+	}
+	for _, version := range crd.Spec.Versions {
+		data := preserveUnknownFieldsSchema
+		if version.Schema != nil && len(version.Schema.OpenAPIV3Schema) > 0 {
+			data = version.Schema.OpenAPIV3Schema
+		} else if crd.Spec.Validation != nil && len(crd.Spec.Validation.OpenAPIV3Schema) > 0 {
+			data = crd.Spec.Validation.OpenAPIV3Schema
+		}
+		scm := &openapi3.Schema{}
+		if err := json.Unmarshal(data, scm); err != nil {
+			return fmt.Errorf("invalid openAPIV3Schema of %s/%s %s: %w", crd.Spec.Group, version.Name, crd.Spec.Names.Kind, err)
+		}
+		gvk, _ := json.Marshal([]map[string]string{{"group": crd.Spec.Group, "version": version.Name, "kind": crd.Spec.Names.Kind}})
+		if scm.Extensions == nil {
+			scm.Extensions = map[string]interface{}{}
+		}
+		scm.Extensions["x-kubernetes-group-version-kind"] = json.RawMessage(gvk)
+		key := crd.componentKey(version.Name)
+		if ks.T.Components.Schemas == nil {
+			ks.T.Components.Schemas = openapi3.Schemas{}
+		}
+		ks.T.Components.Schemas[key] = openapi3.NewSchemaRef("", scm)
+		ki := &KindInfo{
+			Version:      version.Name,
+			Group:        crd.Spec.Group,
+			ComponentKey: key,
+			IsGA:         getVersionType(version.Name) == gaVersion,
+			IsStorage:    version.Storage,
+			Deprecated:   version.Deprecated,
+		}
+		if version.Served {
+			ki.RestPath = fmt.Sprintf("/apis/%s/%s/%s", crd.Spec.Group, version.Name, crd.Spec.Names.Plural)
+		}
+		if version.Deprecated {
+			// same default as the api server uses in the Warning header
+			ki.DeprecationWarning = fmt.Sprintf("%s/%s %s is deprecated", crd.Spec.Group, version.Name, crd.Spec.Names.Kind)
+			if version.DeprecationWarning != nil {
+				ki.DeprecationWarning = *version.DeprecationWarning
+			}
+		}
+		kindInfos = append(kindInfos, ki)
+	}
+	sort.Slice(kindInfos, func(i, j int) bool {
+		return compareVersion(kindInfos[i].Version, kindInfos[j].Version)
+	})
+	ks.kindInfoMap[kind] = kindInfos
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+const testWidgetCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+  versions:
+  - name: v1alpha1
+    served: false
+    storage: false
+  - name: v1beta1
+    served: true
+    storage: false
+    deprecated: true
+    deprecationWarning: example.com/v1beta1 Widget is deprecated; use example.com/v1 Widget
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            properties:
+              size:
+                type: string
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required: ["size"]
+            properties:
+              size:
+                type: integer
+`
+
+func TestAddCustomResourceDefinition(t *testing.T) {
+	crd := map[string]interface{}{}
+	assert.NoError(t, yaml.Unmarshal([]byte(testWidgetCRD), &crd))
+	assert.True(t, IsCustomResourceDefinition(crd))
+	specFile := filepath.Join(t.TempDir(), "swagger.json")
+	assert.NoError(t, ioutil.WriteFile(specFile, []byte(testSwaggerSpec), 0644))
+
+	tests := []struct {
+		name              string
+		widget            string
+		wantErrors        bool
+		wantDeprecated    bool
+		wantWarning       string
+		wantDeleted       bool
+		wantLatestVersion string
+	}{
+		{
+			name:   "valid widget of storage version",
+			widget: `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "w"}, "spec": {"size": 3}}`,
+		},
+		{
+			name:       "invalid widget of storage version",
+			widget:     `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "w"}, "spec": {"size": "big"}}`,
+			wantErrors: true,
+		},
+		{
+			name:              "widget of deprecated version",
+			widget:            `{"apiVersion": "example.com/v1beta1", "kind": "Widget", "metadata": {"name": "w"}, "spec": {"size": "big"}}`,
+			wantDeprecated:    true,
+			wantWarning:       "example.com/v1beta1 Widget is deprecated; use example.com/v1 Widget",
+			wantLatestVersion: "example.com/v1",
+		},
+		{
+			name:              "widget of version which is not served",
+			widget:            `{"apiVersion": "example.com/v1alpha1", "kind": "Widget", "metadata": {"name": "w"}, "spec": {}}`,
+			wantDeleted:       true,
+			wantLatestVersion: "example.com/v1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// crd is registered before the spec is loaded to ensure it is applied to specs loaded later
+			kc := NewKubeCheckerImpl()
+			assert.NoError(t, kc.AddCustomResourceDefinition(crd))
+			assert.NoError(t, kc.LoadFromPath("1.22", specFile, false))
+			assert.True(t, kc.IsApiVersionSupported("1.22", "example.com/v1", "Widget"))
+			result, err := kc.ValidateJson(tt.widget, "1.22")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantErrors, len(result.ErrorsForOriginal) > 0)
+			assert.Equal(t, tt.wantDeprecated, result.Deprecated)
+			assert.Equal(t, tt.wantWarning, result.DeprecationWarning)
+			assert.Equal(t, tt.wantDeleted, result.Deleted)
+			assert.Equal(t, tt.wantLatestVersion, result.LatestAPIVersion)
+		})
+	}
+
+	_, err := parseCustomResourceDefinition(map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap"})
+	assert.Error(t, err)
+}
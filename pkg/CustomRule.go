@@ -0,0 +1,206 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/ext"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// CustomRulesVersion is the version of the format of custom rule files understood by kubedd
+	CustomRulesVersion = 1
+
+	// customRuleCostLimit bounds the cost of evaluating an expression against an object, as kubernetes does for
+	// validation rules, so that a rule can not stall validation
+	customRuleCostLimit = 1000000
+)
+
+// CustomRule is a check which kubernetes objects selected by APIVersions, Kinds and Versions must pass, Expression is
+// a CEL expression evaluated against the object, bound to `object`, which is true for objects passing the check
+type CustomRule struct {
+	ID          string   `json:"id"`
+	APIVersions []string `json:"apiVersions,omitempty"`
+	Kinds       []string `json:"kinds,omitempty"`
+	Versions    string   `json:"versions,omitempty"`
+	Expression  string   `json:"expression"`
+	Severity    string   `json:"severity,omitempty"`
+	Message     string   `json:"message"`
+	Remediation string   `json:"remediation,omitempty"`
+
+	program cel.Program
+}
+
+// CustomRules is a versioned set of custom rules, as stored in a file of rules directory
+type CustomRules struct {
+	Version int          `json:"version"`
+	Rules   []CustomRule `json:"rules"`
+}
+
+// LoadCustomRules returns rules of every .yaml, .yml and .json file of dir with their expressions compiled, rule ids
+// are unique across files
+func LoadCustomRules(dir string) (*CustomRules, error) {
+	rules := &CustomRules{Version: CustomRulesVersion}
+	if len(dir) == 0 {
+		return rules, nil
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	env, err := newCustomRuleEnv()
+	if err != nil {
+		return nil, err
+	}
+	ids := map[string]string{}
+	for _, file := range files {
+		switch strings.ToLower(filepath.Ext(file.Name())) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		if file.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		fileRules, err := parseCustomRules(env, data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid custom rules %s: %w", path, err)
+		}
+		for _, rule := range fileRules.Rules {
+			if other, ok := ids[rule.ID]; ok {
+				return nil, fmt.Errorf("custom rule %s of %s is already defined in %s", rule.ID, path, other)
+			}
+			ids[rule.ID] = path
+			rules.Rules = append(rules.Rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func newCustomRuleEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("object", cel.DynType),
+		cel.CrossTypeNumericComparisons(true),
+		ext.Strings(),
+	)
+}
+
+func parseCustomRules(env *cel.Env, data []byte) (*CustomRules, error) {
+	rules := &CustomRules{}
+	if err := yaml.UnmarshalStrict(data, rules); err != nil {
+		return nil, err
+	}
+	if rules.Version != CustomRulesVersion {
+		return nil, fmt.Errorf("unsupported version %d of custom rules, expected %d", rules.Version, CustomRulesVersion)
+	}
+	ids := map[string]bool{}
+	for i := range rules.Rules {
+		rule := &rules.Rules[i]
+		if len(rule.ID) == 0 {
+			return nil, fmt.Errorf("custom rule %d has no id", i)
+		}
+		if ids[rule.ID] {
+			return nil, fmt.Errorf("duplicate custom rule %s", rule.ID)
+		}
+		ids[rule.ID] = true
+		if len(rule.Severity) == 0 {
+			rule.Severity = SeverityWarning
+		}
+		switch rule.Severity {
+		case SeverityInfo, SeverityWarning, SeverityError:
+		default:
+			return nil, fmt.Errorf("invalid severity %q of custom rule %s", rule.Severity, rule.ID)
+		}
+		if _, _, err := parseReleaseRange(rule.Versions); err != nil {
+			return nil, fmt.Errorf("invalid versions of custom rule %s: %w", rule.ID, err)
+		}
+		if len(rule.Message) == 0 {
+			return nil, fmt.Errorf("custom rule %s has no message", rule.ID)
+		}
+		if err := rule.compile(env); err != nil {
+			return nil, fmt.Errorf("invalid expression of custom rule %s: %w", rule.ID, err)
+		}
+	}
+	return rules, nil
+}
+
+func (r *CustomRule) compile(env *cel.Env) error {
+	ast, issues := env.Compile(r.Expression)
+	if issues != nil && issues.Err() != nil {
+		return issues.Err()
+	}
+	if outputType := ast.OutputType(); !outputType.IsExactType(types.BoolType) && !outputType.IsExactType(types.DynType) {
+		return fmt.Errorf("expression evaluates to %s instead of bool", outputType)
+	}
+	program, err := env.Program(ast, cel.CostLimit(customRuleCostLimit))
+	if err != nil {
+		return err
+	}
+	r.program = program
+	return nil
+}
+
+// evaluate returns violation of the rule by object, if any, expressions which fail to evaluate, eg because they
+// refer to a field missing in object without has(), are reported as violations so that they are not missed
+func (r *CustomRule) evaluate(object map[string]interface{}) *SchemaError {
+	out, _, err := r.program.Eval(map[string]interface{}{"object": object})
+	reason := r.Message
+	if err != nil {
+		reason = fmt.Sprintf("%s: unable to evaluate %s: %v", r.Message, r.Expression, err)
+	} else if passed, ok := out.Value().(bool); !ok {
+		reason = fmt.Sprintf("%s: %s evaluates to %v instead of bool", r.Message, r.Expression, out.Value())
+	} else if passed {
+		return nil
+	}
+	return &SchemaError{Reason: reason, RuleID: r.ID, Severity: r.Severity, Remediation: r.Remediation}
+}
+
+// violations returns violations of rules applying to object of apiVersion and kind in releaseVersion ordered by
+// rule id
+func (rs *CustomRules) violations(object map[string]interface{}, apiVersion, kind, releaseVersion string) []*SchemaError {
+	if rs == nil {
+		return nil
+	}
+	var violations []*SchemaError
+	for i := range rs.Rules {
+		rule := &rs.Rules[i]
+		if !matchesResource(rule.APIVersions, rule.Kinds, rule.Versions, apiVersion, kind, releaseVersion) {
+			continue
+		}
+		if violation := rule.evaluate(object); violation != nil {
+			violations = append(violations, violation)
+		}
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].RuleID < violations[j].RuleID
+	})
+	return violations
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testCustomRules = `version: 1
+rules:
+- id: no-k8s-gcr-images
+  apiVersions: [apps/*]
+  kinds: [Deployment]
+  expression: "object.spec.template.spec.containers.all(c, !c.image.startsWith('k8s.gcr.io/'))"
+  severity: error
+  message: k8s.gcr.io is frozen
+  remediation: pull images from registry.k8s.io
+- id: hpa-behavior
+  kinds: [HorizontalPodAutoscaler]
+  versions: "1.27.."
+  expression: has(object.spec.behavior)
+  message: behavior must be set
+- id: min-replicas
+  kinds: [Deployment]
+  expression: object.spec.replicas >= 2
+  severity: info
+  message: run at least two replicas
+`
+
+func TestLoadCustomRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantIDs []string
+		wantErr bool
+	}{
+		{name: "no directory"},
+		{name: "rules of yaml files", files: map[string]string{"rules.yaml": testCustomRules, "README.md": "# rules"},
+			wantIDs: []string{"no-k8s-gcr-images", "hpa-behavior", "min-replicas"}},
+		{name: "duplicate id across files", files: map[string]string{"a.yaml": testCustomRules, "b.yml": testCustomRules}, wantErr: true},
+		{name: "invalid expression", files: map[string]string{"a.yaml": "version: 1\nrules:\n- id: a\n  expression: object.spec.(\n  message: m\n"}, wantErr: true},
+		{name: "expression which is not bool", files: map[string]string{"a.yaml": "version: 1\nrules:\n- id: a\n  expression: \"'a'\"\n  message: m\n"}, wantErr: true},
+		{name: "missing message", files: map[string]string{"a.yaml": "version: 1\nrules:\n- id: a\n  expression: \"true\"\n"}, wantErr: true},
+		{name: "invalid severity", files: map[string]string{"a.yaml": "version: 1\nrules:\n- id: a\n  expression: \"true\"\n  message: m\n  severity: none\n"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := ""
+			if tt.files != nil {
+				dir = t.TempDir()
+				for name, content := range tt.files {
+					assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+				}
+			}
+			rules, err := LoadCustomRules(dir)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			var ids []string
+			for _, rule := range rules.Rules {
+				ids = append(ids, rule.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestCustomRuleViolations(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "rules.yaml"), []byte(testCustomRules), 0644))
+	rules, err := LoadCustomRules(dir)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		apiVersion     string
+		kind           string
+		object         string
+		releaseVersion string
+		wantIDs        []string
+		wantSeverities []string
+	}{
+		{name: "passing deployment", apiVersion: "apps/v1", kind: "Deployment", releaseVersion: "1.29",
+			object: `{"spec":{"replicas":3,"template":{"spec":{"containers":[{"image":"registry.k8s.io/pause"}]}}}}`},
+		{name: "violating deployment", apiVersion: "apps/v1", kind: "Deployment", releaseVersion: "1.29",
+			object:  `{"spec":{"replicas":1,"template":{"spec":{"containers":[{"image":"k8s.gcr.io/pause"}]}}}}`,
+			wantIDs: []string{"min-replicas", "no-k8s-gcr-images"}, wantSeverities: []string{SeverityInfo, SeverityError}},
+		{name: "expression which fails to evaluate", apiVersion: "apps/v1", kind: "Deployment", releaseVersion: "1.29",
+			object:  `{"spec":{"replicas":2}}`,
+			wantIDs: []string{"no-k8s-gcr-images"}, wantSeverities: []string{SeverityError}},
+		{name: "release before rule applies", apiVersion: "autoscaling/v2", kind: "HorizontalPodAutoscaler", releaseVersion: "1.26",
+			object: `{"spec":{}}`},
+		{name: "release rule applies to", apiVersion: "autoscaling/v2", kind: "HorizontalPodAutoscaler", releaseVersion: "1.27",
+			object:  `{"spec":{}}`,
+			wantIDs: []string{"hpa-behavior"}, wantSeverities: []string{SeverityWarning}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var object map[string]interface{}
+			assert.NoError(t, json.Unmarshal([]byte(tt.object), &object))
+			var ids, severities []string
+			for _, violation := range rules.violations(object, tt.apiVersion, tt.kind, tt.releaseVersion) {
+				ids = append(ids, violation.RuleID)
+				severities = append(severities, violation.Severity)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantSeverities, severities)
+		})
+	}
+}
@@ -0,0 +1,440 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	_ "embed"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DeprecationRulesVersion is the version of the format of deprecation rule files understood by kubedd
+	DeprecationRulesVersion = 1
+
+	SeverityInfo    = "info"
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+	// SeverityNone suppresses findings at path of the rule, deprecations mentioned in descriptions of fields which
+	// are not deprecated themselves for instance
+	SeverityNone = "none"
+
+	anyFieldPattern  = "*"
+	anyFieldsPattern = "**"
+)
+
+//go:embed rules/deprecations.yaml
+var defaultDeprecationRules []byte
+
+var (
+	// deprecationNotice matches descriptions of deprecated fields and kinds as worded in kubernetes api, unlike
+	// descriptions which merely mention something deprecated
+	deprecationNotice          = regexp.MustCompile(`(?i)(^\s*deprecated\b|\bdeprecated:|\b(is|are|was|were|been|now) deprecated\b|\bdeprecated (in|since|and|as of|in favou?r)\b)`)
+	upperCaseDeprecationNotice = regexp.MustCompile(`\bDEPRECATED\b`)
+)
+
+// DeprecationRule describes a deprecated field, or a deprecated kind if Path is empty, see rules/deprecations.yaml
+type DeprecationRule struct {
+	ID          string   `json:"id"`
+	APIVersions []string `json:"apiVersions,omitempty"`
+	Kinds       []string `json:"kinds,omitempty"`
+	Path        string   `json:"path,omitempty"`
+	Versions    string   `json:"versions,omitempty"`
+	Severity    string   `json:"severity,omitempty"`
+	Replacement string   `json:"replacement,omitempty"`
+	Remediation string   `json:"remediation,omitempty"`
+	Disabled    bool     `json:"disabled,omitempty"`
+}
+
+// DeprecationRules is a versioned set of deprecation rules
+type DeprecationRules struct {
+	Version int               `json:"version"`
+	Rules   []DeprecationRule `json:"rules"`
+}
+
+// DefaultDeprecationRules returns deprecation rules shipped with kubedd
+func DefaultDeprecationRules() (*DeprecationRules, error) {
+	return parseDeprecationRules(defaultDeprecationRules)
+}
+
+// LoadDeprecationRules returns deprecation rules shipped with kubedd overridden by those in file at path, if any.
+// Rules of the file replace shipped rules of the same id, disabled ones are dropped.
+func LoadDeprecationRules(path string) (*DeprecationRules, error) {
+	rules, err := DefaultDeprecationRules()
+	if err != nil || len(path) == 0 {
+		return rules, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := parseDeprecationRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deprecation rules %s: %w", path, err)
+	}
+	return rules.merge(overrides), nil
+}
+
+func parseDeprecationRules(data []byte) (*DeprecationRules, error) {
+	rules := &DeprecationRules{}
+	if err := yaml.UnmarshalStrict(data, rules); err != nil {
+		return nil, err
+	}
+	if rules.Version != DeprecationRulesVersion {
+		return nil, fmt.Errorf("unsupported version %d of deprecation rules, expected %d", rules.Version, DeprecationRulesVersion)
+	}
+	ids := map[string]bool{}
+	for i := range rules.Rules {
+		rule := &rules.Rules[i]
+		if len(rule.ID) == 0 {
+			return nil, fmt.Errorf("deprecation rule %d has no id", i)
+		}
+		if ids[rule.ID] {
+			return nil, fmt.Errorf("duplicate deprecation rule %s", rule.ID)
+		}
+		ids[rule.ID] = true
+		if len(rule.Severity) == 0 {
+			rule.Severity = SeverityWarning
+		}
+		switch rule.Severity {
+		case SeverityInfo, SeverityWarning, SeverityError, SeverityNone:
+		default:
+			return nil, fmt.Errorf("invalid severity %q of deprecation rule %s", rule.Severity, rule.ID)
+		}
+		if _, _, err := rule.releaseRange(); err != nil {
+			return nil, fmt.Errorf("invalid versions of deprecation rule %s: %w", rule.ID, err)
+		}
+	}
+	return rules, nil
+}
+
+// merge returns rules with those of overrides replacing rules of the same id
+func (rs *DeprecationRules) merge(overrides *DeprecationRules) *DeprecationRules {
+	merged := &DeprecationRules{Version: rs.Version}
+	overridden := map[string]DeprecationRule{}
+	for _, rule := range overrides.Rules {
+		overridden[rule.ID] = rule
+	}
+	for _, rule := range rs.Rules {
+		if override, ok := overridden[rule.ID]; ok {
+			rule = override
+			delete(overridden, rule.ID)
+		}
+		if !rule.Disabled {
+			merged.Rules = append(merged.Rules, rule)
+		}
+	}
+	for _, rule := range overrides.Rules {
+		if _, ok := overridden[rule.ID]; ok && !rule.Disabled {
+			merged.Rules = append(merged.Rules, rule)
+		}
+	}
+	return merged
+}
+
+// releaseRange returns releases from and to of Versions, either of which may be empty
+func (r *DeprecationRule) releaseRange() (from, to string, err error) {
+	return parseReleaseRange(r.Versions)
+}
+
+// appliesTo tells if the rule applies to kind of apiVersion in releaseVersion
+func (r *DeprecationRule) appliesTo(apiVersion, kind, releaseVersion string) bool {
+	return !r.Disabled && matchesResource(r.APIVersions, r.Kinds, r.Versions, apiVersion, kind, releaseVersion)
+}
+
+// parseReleaseRange returns releases from and to of versions, <from>..<to>, either of which may be empty
+func parseReleaseRange(versions string) (from, to string, err error) {
+	if len(versions) == 0 {
+		return "", "", nil
+	}
+	ends := strings.Split(versions, upgradePathSeparator)
+	if len(ends) != 2 {
+		return "", "", fmt.Errorf("expected <from>..<to> eg 1.21.. got %q", versions)
+	}
+	for _, end := range ends {
+		if len(end) > 0 {
+			if _, _, err := parseReleaseVersion(end); err != nil {
+				return "", "", err
+			}
+		}
+	}
+	return ends[0], ends[1], nil
+}
+
+// matchesResource tells if kind of apiVersion in releaseVersion is selected by apiVersions, kinds and range of
+// releases versions, empty selectors select everything. Releases which are not kubernetes versions, labels of specs
+// loaded from a path for instance, are in every range.
+func matchesResource(apiVersions, kinds []string, versions, apiVersion, kind, releaseVersion string) bool {
+	if len(apiVersions) > 0 && !Contains(apiVersion, apiVersions) {
+		return false
+	}
+	if len(kinds) > 0 && !Contains(kind, kinds) {
+		return false
+	}
+	from, to, err := parseReleaseRange(versions)
+	if err != nil {
+		return false
+	}
+	if _, _, err := parseReleaseVersion(releaseVersion); err != nil {
+		return true
+	}
+	if len(from) > 0 && compareReleaseVersion(releaseVersion, from) {
+		return false
+	}
+	return len(to) == 0 || !compareReleaseVersion(to, releaseVersion)
+}
+
+func (r *DeprecationRule) reason(subject string) string {
+	if len(r.Replacement) == 0 {
+		return fmt.Sprintf("%s is deprecated", subject)
+	}
+	return fmt.Sprintf("%s is deprecated, use %s instead", subject, r.Replacement)
+}
+
+// annotate sets id, severity and remediation of the rule on finding
+func (r *DeprecationRule) annotate(finding *SchemaError) {
+	finding.RuleID = r.ID
+	finding.Severity = r.Severity
+	finding.Remediation = r.Remediation
+}
+
+// fieldFindings returns findings with those of field rules applying to fields of object added, findings at paths of
+// field rules are annotated with the rule and those of rules of severity none are dropped
+func (rs *DeprecationRules) fieldFindings(object map[string]interface{}, apiVersion, kind, releaseVersion string, findings []*SchemaError) []*SchemaError {
+	if rs == nil {
+		return findings
+	}
+	var paths [][]string
+	for _, rule := range rs.Rules {
+		if len(rule.Path) == 0 || !rule.appliesTo(apiVersion, kind, releaseVersion) {
+			continue
+		}
+		if paths == nil {
+			paths = fieldPaths(object, nil)
+		}
+		pattern := strings.Split(rule.Path, "/")
+		for _, path := range paths {
+			if !matchFieldPath(pattern, path) {
+				continue
+			}
+			key := strings.Join(path, "/")
+			var existing *SchemaError
+			for _, finding := range findings {
+				if strings.Join(finding.JSONPointer(), "/") == key {
+					existing = finding
+					break
+				}
+			}
+			if rule.Severity == SeverityNone {
+				findings = removeFinding(findings, existing)
+				continue
+			}
+			if existing == nil {
+				existing = &SchemaError{Reason: rule.reason(key), SchemaField: path[len(path)-1], reversePath: reversed(path)}
+				findings = append(findings, existing)
+			}
+			rule.annotate(existing)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return strings.Join(findings[i].JSONPointer(), "/") < strings.Join(findings[j].JSONPointer(), "/")
+	})
+	return findings
+}
+
+// kindFinding returns finding of the first kind rule applying to kind of apiVersion in releaseVersion, if any
+func (rs *DeprecationRules) kindFinding(apiVersion, kind, releaseVersion string) *SchemaError {
+	rule := rs.kindRule(apiVersion, kind, releaseVersion)
+	if rule == nil || rule.Severity == SeverityNone {
+		return nil
+	}
+	finding := &SchemaError{Reason: rule.reason(fmt.Sprintf("%s %s", apiVersion, kind))}
+	rule.annotate(finding)
+	return finding
+}
+
+// kindRule returns the first kind rule applying to kind of apiVersion in releaseVersion, if any
+func (rs *DeprecationRules) kindRule(apiVersion, kind, releaseVersion string) *DeprecationRule {
+	if rs == nil {
+		return nil
+	}
+	for i := range rs.Rules {
+		if rule := &rs.Rules[i]; len(rule.Path) == 0 && rule.appliesTo(apiVersion, kind, releaseVersion) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// fieldRule returns the first field rule applying to kind of apiVersion in releaseVersion whose pattern matches
+// path, if any
+func (rs *DeprecationRules) fieldRule(path []string, apiVersion, kind, releaseVersion string) *DeprecationRule {
+	if rs == nil {
+		return nil
+	}
+	for i := range rs.Rules {
+		rule := &rs.Rules[i]
+		if len(rule.Path) > 0 && rule.appliesTo(apiVersion, kind, releaseVersion) && matchFieldPath(strings.Split(rule.Path, "/"), path) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// deprecationCheck tells if fields and kind of a resource are deprecated, rules applying to them decide it and
+// descriptions of their schemas are looked at only if no rule applies
+type deprecationCheck struct {
+	rules          *DeprecationRules
+	apiVersion     string
+	kind           string
+	releaseVersion string
+}
+
+// fieldFinding returns finding for field at path, whose schema is schema, if it is deprecated. Findings for the
+// object itself, at empty path, are left to kind rules if any applies.
+func (c *deprecationCheck) fieldFinding(path []string, schema *openapi3.Schema) *SchemaError {
+	if c != nil {
+		if len(path) == 0 {
+			if c.rules.kindRule(c.apiVersion, c.kind, c.releaseVersion) != nil {
+				return nil
+			}
+		} else if rule := c.rules.fieldRule(path, c.apiVersion, c.kind, c.releaseVersion); rule != nil {
+			if rule.Severity == SeverityNone {
+				return nil
+			}
+			finding := &SchemaError{Value: "", Schema: schema, Reason: rule.reason(strings.Join(path, "/"))}
+			rule.annotate(finding)
+			return finding
+		}
+	}
+	if isDeprecatedDescription(schema.Description) {
+		return &SchemaError{Value: "", Schema: schema, Reason: schema.Description}
+	}
+	return nil
+}
+
+// isDeprecatedKind tells if the kind, whose schema is schema, is deprecated
+func (c *deprecationCheck) isDeprecatedKind(schema *openapi3.Schema) bool {
+	if c != nil {
+		if rule := c.rules.kindRule(c.apiVersion, c.kind, c.releaseVersion); rule != nil {
+			return rule.Severity != SeverityNone
+		}
+	}
+	return isDeprecatedDescription(schema.Description)
+}
+
+// applyDeprecationRules annotates deprecations of result with rules applying in releaseVersion, the resource is
+// marked deprecated if a kind rule applies to its apiVersion
+func (rs *DeprecationRules) applyDeprecationRules(result *ValidationResult, object map[string]interface{}, releaseVersion string) {
+	if rs == nil || len(result.Kind) == 0 {
+		return
+	}
+	result.DeprecationForOriginal = rs.fieldFindings(object, result.APIVersion, result.Kind, releaseVersion, result.DeprecationForOriginal)
+	if len(result.LatestAPIVersion) > 0 {
+		result.DeprecationForLatest = rs.fieldFindings(object, result.LatestAPIVersion, result.Kind, releaseVersion, result.DeprecationForLatest)
+	}
+	if finding := rs.kindFinding(result.APIVersion, result.Kind, releaseVersion); finding != nil {
+		result.DeprecationForOriginal = append([]*SchemaError{finding}, result.DeprecationForOriginal...)
+		result.Deprecated = result.Deprecated || !result.Deleted
+		if len(result.DeprecationWarning) == 0 {
+			result.DeprecationWarning = finding.Reason
+		}
+	}
+}
+
+// fieldPaths returns path of every field and item of value, parent first
+func fieldPaths(value interface{}, parent []string) [][]string {
+	var paths [][]string
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, v := range value {
+			path := append(append([]string(nil), parent...), key)
+			paths = append(paths, path)
+			paths = append(paths, fieldPaths(v, path)...)
+		}
+	case []interface{}:
+		for i, v := range value {
+			path := append(append([]string(nil), parent...), strconv.Itoa(i))
+			paths = append(paths, path)
+			paths = append(paths, fieldPaths(v, path)...)
+		}
+	}
+	return paths
+}
+
+// matchFieldPath tells if path matches pattern whose elements are names of fields, * for any field or index, **
+// for any number of them or {a,b} for either a or b
+func matchFieldPath(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+	if pattern[0] == anyFieldsPattern {
+		for i := 0; i <= len(path); i++ {
+			if matchFieldPath(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 || !matchField(pattern[0], path[0]) {
+		return false
+	}
+	return matchFieldPath(pattern[1:], path[1:])
+}
+
+func matchField(pattern, field string) bool {
+	if pattern == anyFieldPattern || pattern == field {
+		return true
+	}
+	if strings.HasPrefix(pattern, "{") && strings.HasSuffix(pattern, "}") {
+		for _, alternative := range strings.Split(pattern[1:len(pattern)-1], ",") {
+			if strings.TrimSpace(alternative) == field {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func removeFinding(findings []*SchemaError, finding *SchemaError) []*SchemaError {
+	if finding == nil {
+		return findings
+	}
+	var remaining []*SchemaError
+	for _, f := range findings {
+		if f != finding {
+			remaining = append(remaining, f)
+		}
+	}
+	return remaining
+}
+
+func reversed(path []string) []string {
+	reversePath := make([]string, 0, len(path))
+	for i := len(path) - 1; i >= 0; i-- {
+		reversePath = append(reversePath, path[i])
+	}
+	return reversePath
+}
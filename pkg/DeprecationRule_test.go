@@ -0,0 +1,271 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchFieldPath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "spec/loadBalancerIP", path: "spec/loadBalancerIP", want: true},
+		{pattern: "spec/loadBalancerIP", path: "spec/ports", want: false},
+		{pattern: "spec/volumes/*/gitRepo", path: "spec/volumes/0/gitRepo", want: true},
+		{pattern: "spec/volumes/*/gitRepo", path: "spec/volumes/gitRepo", want: false},
+		{pattern: "**/spec/serviceAccount", path: "spec/serviceAccount", want: true},
+		{pattern: "**/spec/serviceAccount", path: "spec/template/spec/serviceAccount", want: true},
+		{pattern: "**/spec/serviceAccount", path: "spec/template/spec/serviceAccountName", want: false},
+		{pattern: "**/{cephfs,rbd}", path: "spec/volumes/1/rbd", want: true},
+		{pattern: "**/{cephfs,rbd}", path: "spec/volumes/1/nfs", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchFieldPath(strings.Split(tt.pattern, "/"), strings.Split(tt.path, "/")))
+		})
+	}
+}
+
+func TestDeprecationRuleAppliesTo(t *testing.T) {
+	tests := []struct {
+		name           string
+		rule           DeprecationRule
+		apiVersion     string
+		releaseVersion string
+		want           bool
+	}{
+		{name: "any release", rule: DeprecationRule{}, apiVersion: "v1", releaseVersion: "1.20", want: true},
+		{name: "before range", rule: DeprecationRule{Versions: "1.24.."}, apiVersion: "v1", releaseVersion: "1.23", want: false},
+		{name: "start of range", rule: DeprecationRule{Versions: "1.24.."}, apiVersion: "v1", releaseVersion: "1.24", want: true},
+		{name: "end of range", rule: DeprecationRule{Versions: "..1.25"}, apiVersion: "v1", releaseVersion: "1.25", want: true},
+		{name: "after range", rule: DeprecationRule{Versions: "1.21..1.24"}, apiVersion: "v1", releaseVersion: "1.25", want: false},
+		{name: "release which is not a version", rule: DeprecationRule{Versions: "1.24.."}, apiVersion: "v1", releaseVersion: "local", want: true},
+		{name: "other api version", rule: DeprecationRule{APIVersions: []string{"apps/v1beta1"}}, apiVersion: "apps/v1", releaseVersion: "1.20", want: false},
+		{name: "wildcard api version", rule: DeprecationRule{APIVersions: []string{"apps/*"}}, apiVersion: "apps/v1", releaseVersion: "1.20", want: true},
+		{name: "disabled", rule: DeprecationRule{Disabled: true}, apiVersion: "v1", releaseVersion: "1.20", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.appliesTo(tt.apiVersion, "Service", tt.releaseVersion))
+		})
+	}
+}
+
+func TestDefaultDeprecationRules(t *testing.T) {
+	rules, err := DefaultDeprecationRules()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, rules.Rules)
+	ids := map[string]bool{}
+	for _, rule := range rules.Rules {
+		assert.NotEmpty(t, rule.ID)
+		assert.False(t, ids[rule.ID], "duplicate rule %s", rule.ID)
+		ids[rule.ID] = true
+	}
+	assert.NotPanics(t, func() {
+		assert.Equal(t, rules, NewKubeCheckerImpl().deprecationRules)
+	})
+}
+
+func TestLoadDeprecationRules(t *testing.T) {
+	defaults, err := DefaultDeprecationRules()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, defaults.Rules)
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+		check   func(t *testing.T, rules *DeprecationRules)
+	}{
+		{name: "override, disable and add rules", content: `version: 1
+rules:
+- id: service-load-balancer-ip
+  path: spec/loadBalancerIP
+  severity: error
+- id: node-external-id
+  disabled: true
+- id: example-widget-size
+  kinds: [Widget]
+  path: spec/size
+`, check: func(t *testing.T, rules *DeprecationRules) {
+			ids := map[string]DeprecationRule{}
+			for _, rule := range rules.Rules {
+				ids[rule.ID] = rule
+			}
+			assert.Len(t, rules.Rules, len(defaults.Rules))
+			assert.Equal(t, SeverityError, ids["service-load-balancer-ip"].Severity)
+			assert.NotContains(t, ids, "node-external-id")
+			assert.Equal(t, SeverityWarning, ids["example-widget-size"].Severity)
+		}},
+		{name: "unsupported version", content: "version: 2\nrules: []\n", wantErr: true},
+		{name: "invalid severity", content: "version: 1\nrules:\n- id: a\n  severity: fatal\n", wantErr: true},
+		{name: "invalid versions", content: "version: 1\nrules:\n- id: a\n  versions: \"1.21\"\n", wantErr: true},
+		{name: "duplicate ids", content: "version: 1\nrules:\n- id: a\n- id: a\n", wantErr: true},
+		{name: "unknown field", content: "version: 1\nrules:\n- id: a\n  pattern: spec\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.yaml")
+			assert.NoError(t, ioutil.WriteFile(path, []byte(tt.content), 0644))
+			rules, err := LoadDeprecationRules(path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			tt.check(t, rules)
+		})
+	}
+}
+
+func TestApplyDeprecationRules(t *testing.T) {
+	rules := &DeprecationRules{Version: DeprecationRulesVersion, Rules: []DeprecationRule{
+		{ID: "pod-service-account", Path: "**/spec/serviceAccount", Severity: SeverityWarning, Replacement: "serviceAccountName", Remediation: "rename it"},
+		{ID: "load-balancer-ip", Kinds: []string{"Service"}, Path: "spec/loadBalancerIP", Versions: "1.24..", Severity: SeverityWarning},
+		{ID: "crd-deprecated-flag", Path: "spec/versions/*/deprecated", Severity: SeverityNone},
+		{ID: "psp", APIVersions: []string{"policy/v1beta1"}, Kinds: []string{"PodSecurityPolicy"}, Severity: SeverityError, Remediation: "use pod security admission"},
+	}}
+	tests := []struct {
+		name           string
+		result         ValidationResult
+		object         string
+		releaseVersion string
+		wantPaths      []string
+		wantRuleIDs    []string
+		wantDeprecated bool
+	}{
+		{name: "finding in description is annotated",
+			result: ValidationResult{Kind: "Deployment", APIVersion: "apps/v1", DeprecationForOriginal: []*SchemaError{
+				{Reason: "deprecated", reversePath: []string{"serviceAccount", "spec", "template", "spec"}}}},
+			object:         `{"spec":{"template":{"spec":{"serviceAccount":"a"}}}}`,
+			releaseVersion: "1.29", wantPaths: []string{"spec/template/spec/serviceAccount"}, wantRuleIDs: []string{"pod-service-account"}},
+		{name: "finding missing in description is added",
+			result:         ValidationResult{Kind: "Service", APIVersion: "v1"},
+			object:         `{"spec":{"loadBalancerIP":"10.0.0.1"}}`,
+			releaseVersion: "1.24", wantPaths: []string{"spec/loadBalancerIP"}, wantRuleIDs: []string{"load-balancer-ip"}},
+		{name: "rule of later release",
+			result:         ValidationResult{Kind: "Service", APIVersion: "v1"},
+			object:         `{"spec":{"loadBalancerIP":"10.0.0.1"}}`,
+			releaseVersion: "1.23"},
+		{name: "finding is suppressed",
+			result: ValidationResult{Kind: "CustomResourceDefinition", APIVersion: "apiextensions.k8s.io/v1", DeprecationForOriginal: []*SchemaError{
+				{Reason: "deprecated", reversePath: []string{"deprecated", "0", "versions", "spec"}}}},
+			object:         `{"spec":{"versions":[{"deprecated":false}]}}`,
+			releaseVersion: "1.29"},
+		{name: "deprecated kind",
+			result:         ValidationResult{Kind: "PodSecurityPolicy", APIVersion: "policy/v1beta1"},
+			object:         `{"spec":{}}`,
+			releaseVersion: "1.22", wantPaths: []string{""}, wantRuleIDs: []string{"psp"}, wantDeprecated: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var object map[string]interface{}
+			assert.NoError(t, json.Unmarshal([]byte(tt.object), &object))
+			result := tt.result
+			rules.applyDeprecationRules(&result, object, tt.releaseVersion)
+			var paths, ruleIDs []string
+			for _, finding := range result.DeprecationForOriginal {
+				paths = append(paths, strings.Join(finding.JSONPointer(), "/"))
+				ruleIDs = append(ruleIDs, finding.RuleID)
+			}
+			assert.Equal(t, tt.wantPaths, paths)
+			assert.Equal(t, tt.wantRuleIDs, ruleIDs)
+			assert.Equal(t, tt.wantDeprecated, result.Deprecated)
+		})
+	}
+}
+
+func TestIsDeprecatedDescription(t *testing.T) {
+	tests := []struct {
+		description string
+		want        bool
+	}{
+		{description: "Deprecated: Use serviceAccountName instead.", want: true},
+		{description: "DeprecatedServiceAccount is a depreciated alias for ServiceAccountName. Deprecated: Use serviceAccountName instead.", want: true},
+		{description: "This field is deprecated in favour of the loadBalancerClass field.", want: true},
+		{description: "GitRepo represents a git repository at a particular revision. DEPRECATED: GitRepo is deprecated.", want: true},
+		{description: "deprecated indicates this version of the custom resource API is deprecated.", want: true},
+		{description: "deprecationWarning overrides the default warning returned to API clients.", want: false},
+		{description: "Warning messages returned when a deprecated API is used.", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			assert.Equal(t, tt.want, isDeprecatedDescription(tt.description))
+		})
+	}
+}
+
+func TestDeprecationCheck(t *testing.T) {
+	rules := &DeprecationRules{Version: DeprecationRulesVersion, Rules: []DeprecationRule{
+		{ID: "pod-service-account", Path: "**/spec/serviceAccount", Severity: SeverityWarning, Replacement: "serviceAccountName"},
+		{ID: "crd-deprecated-flag", Path: "spec/versions/*/deprecated", Severity: SeverityNone},
+		{ID: "widget", APIVersions: []string{"example.com/v1"}, Kinds: []string{"Widget"}, Severity: SeverityNone},
+	}}
+	deprecated := &openapi3.Schema{Type: "object", Description: "Deprecated: Use something else instead."}
+	tests := []struct {
+		name       string
+		check      *deprecationCheck
+		path       []string
+		schema     *openapi3.Schema
+		wantReason string
+		wantRuleID string
+		wantKind   bool
+	}{
+		{name: "rule decides over description",
+			check:  &deprecationCheck{rules: rules, apiVersion: "apiextensions.k8s.io/v1", kind: "CustomResourceDefinition", releaseVersion: "1.29"},
+			path:   []string{"spec", "versions", "0", "deprecated"},
+			schema: deprecated, wantKind: true},
+		{name: "rule finds field whose description does not mention it",
+			check:  &deprecationCheck{rules: rules, apiVersion: "apps/v1", kind: "Deployment", releaseVersion: "1.29"},
+			path:   []string{"spec", "template", "spec", "serviceAccount"},
+			schema: &openapi3.Schema{Type: "string"}, wantReason: "spec/template/spec/serviceAccount is deprecated, use serviceAccountName instead",
+			wantRuleID: "pod-service-account"},
+		{name: "description decides if no rule applies",
+			check:  &deprecationCheck{rules: rules, apiVersion: "apps/v1", kind: "Deployment", releaseVersion: "1.29"},
+			path:   []string{"spec", "paused"},
+			schema: deprecated, wantReason: deprecated.Description, wantKind: true},
+		{name: "kind rule decides over description",
+			check:  &deprecationCheck{rules: rules, apiVersion: "example.com/v1", kind: "Widget", releaseVersion: "1.29"},
+			schema: deprecated},
+		{name: "description decides without rules",
+			path:   []string{"spec", "template", "spec", "serviceAccount"},
+			schema: deprecated, wantReason: deprecated.Description, wantKind: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finding := tt.check.fieldFinding(tt.path, tt.schema)
+			if len(tt.wantReason) == 0 {
+				assert.Nil(t, finding)
+			} else if assert.NotNil(t, finding) {
+				assert.Equal(t, tt.wantReason, finding.Reason)
+				assert.Equal(t, tt.wantRuleID, finding.RuleID)
+			}
+			assert.Equal(t, tt.wantKind, tt.check.isDeprecatedKind(tt.schema))
+		})
+	}
+}
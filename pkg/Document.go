@@ -0,0 +1,395 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/devtron-labs/silver-surfer/pkg/log"
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+)
+
+// Position is a line and column of a file, both start at 1
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Document is an object decoded from a YAML or JSON stream along with where it is in the stream
+type Document struct {
+	FileName string
+	// Index is the index of the document in the stream, starting at 0, empty documents are not counted
+	Index  int
+	Object map[string]interface{}
+	// ListItemPath is the JSON path of the object in the list document it is expanded from, eg items/3, it is empty
+	// if the document is not a list
+	ListItemPath string
+	Position
+	// positions are positions of fields of the object keyed by their JSON path, eg spec/containers/0/image
+	positions map[string]Position
+}
+
+// DocumentError is an error decoding a document of a stream
+type DocumentError struct {
+	FileName string
+	Index    int
+	Position
+	Err error
+}
+
+func (e *DocumentError) Error() string {
+	location := e.FileName
+	if e.Line > 0 {
+		location = fmt.Sprintf("%s:%d:%d", location, e.Line, e.Column)
+	}
+	if len(location) > 0 {
+		location += ": "
+	}
+	return fmt.Sprintf("%sdocument %d: %v", location, e.Index, e.Err)
+}
+
+func (e *DocumentError) Unwrap() error {
+	return e.Err
+}
+
+// DocumentDecoder decodes objects of a stream of YAML documents, separated by ---, or of concatenated JSON values
+type DocumentDecoder struct {
+	fileName string
+	index    int
+	lines    *lineCounter
+	yaml     *yamlv3.Decoder
+	json     *json.Decoder
+	done     bool
+	// items are objects of a list document which are yet to be returned
+	items []Document
+}
+
+// yamlErrorLine matches line of errors of the YAML parser
+var yamlErrorLine = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// NewDocumentDecoder returns a decoder of documents of r, fileName is reported with documents and errors
+func NewDocumentDecoder(fileName string, r io.Reader) *DocumentDecoder {
+	lines := &lineCounter{r: r}
+	reader := bufio.NewReader(lines)
+	d := &DocumentDecoder{fileName: fileName, lines: lines}
+	if isJSONStream(reader) {
+		d.json = json.NewDecoder(reader)
+	} else {
+		d.yaml = yamlv3.NewDecoder(reader)
+	}
+	return d
+}
+
+// DecodeDocuments returns every document of input, decoding stops at the first error
+func DecodeDocuments(fileName string, input []byte) ([]Document, error) {
+	decoder := NewDocumentDecoder(fileName, bytes.NewReader(input))
+	var documents []Document
+	for {
+		document, err := decoder.Next()
+		if err == io.EOF {
+			return documents, nil
+		}
+		if err != nil {
+			return documents, err
+		}
+		documents = append(documents, document)
+	}
+}
+
+// Next returns the next document of the stream, or io.EOF once the stream ends. Decoding can not resume after an
+// error as the position of the next document is unknown, so io.EOF is returned subsequently.
+func (d *DocumentDecoder) Next() (Document, error) {
+	if len(d.items) > 0 {
+		item := d.items[0]
+		d.items = d.items[1:]
+		return item, nil
+	}
+	if d.done {
+		return Document{}, io.EOF
+	}
+	var document Document
+	var err error
+	if d.json != nil {
+		document, err = d.nextJSON()
+	} else {
+		document, err = d.nextYAML()
+	}
+	if err != nil {
+		d.done = true
+		return Document{}, err
+	}
+	document.FileName = d.fileName
+	document.Index = d.index
+	d.index++
+	if !isListObject(document.Object) {
+		return document, nil
+	}
+	d.items = expandList(document)
+	return d.Next()
+}
+
+func (d *DocumentDecoder) nextYAML() (Document, error) {
+	for {
+		var node yamlv3.Node
+		if err := d.yaml.Decode(&node); err != nil {
+			if err == io.EOF {
+				return Document{}, err
+			}
+			return Document{}, d.yamlError(err)
+		}
+		if len(node.Content) == 0 || node.Content[0].Tag == "!!null" {
+			continue
+		}
+		root := node.Content[0]
+		position := Position{Line: root.Line, Column: root.Column}
+		if root.Kind != yamlv3.MappingNode {
+			return Document{}, d.error(position, fmt.Errorf("document is not an object"))
+		}
+		// objects are decoded by the YAML 1.1 parser kubernetes uses, so that values such as yes and on are read as
+		// the api server reads them
+		manifest, err := yamlv3.Marshal(root)
+		if err != nil {
+			return Document{}, d.error(position, err)
+		}
+		object := map[string]interface{}{}
+		if err := yaml.Unmarshal(manifest, &object); err != nil {
+			return Document{}, d.error(position, err)
+		}
+		positions := map[string]Position{}
+		nodePositions(root, "", Position{}, positions)
+		return Document{Object: object, Position: position, positions: positions}, nil
+	}
+}
+
+func (d *DocumentDecoder) nextJSON() (Document, error) {
+	var raw json.RawMessage
+	if err := d.json.Decode(&raw); err != nil {
+		if err == io.EOF {
+			return Document{}, err
+		}
+		var syntaxError *json.SyntaxError
+		if errors.As(err, &syntaxError) {
+			// offset of syntax errors is past the offending byte
+			return Document{}, d.error(d.lines.position(syntaxError.Offset-1), err)
+		}
+		return Document{}, d.error(Position{}, err)
+	}
+	position := d.lines.position(d.json.InputOffset() - int64(len(raw)))
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return Document{}, d.error(position, fmt.Errorf("document is not an object"))
+	}
+	positions := map[string]Position{}
+	var node yamlv3.Node
+	if err := yamlv3.Unmarshal(raw, &node); err == nil && len(node.Content) > 0 {
+		nodePositions(node.Content[0], "", position, positions)
+	}
+	return Document{Object: object, Position: position, positions: positions}, nil
+}
+
+func (d *DocumentDecoder) error(position Position, err error) error {
+	return &DocumentError{FileName: d.fileName, Index: d.index, Position: position, Err: err}
+}
+
+// yamlError moves line of errors of the YAML parser to the position of the error
+func (d *DocumentDecoder) yamlError(err error) error {
+	message := err.Error()
+	match := yamlErrorLine.FindStringSubmatch(message)
+	if match == nil {
+		return d.error(Position{}, err)
+	}
+	line, _ := strconv.Atoi(match[1])
+	return d.error(Position{Line: line, Column: 1}, errors.New(strings.TrimPrefix(message, match[0])))
+}
+
+// isListObject tells if object is a list of objects, eg v1 List of kubectl get or a DeploymentList
+func isListObject(object map[string]interface{}) bool {
+	kind, _ := object["kind"].(string)
+	if !strings.HasSuffix(kind, "List") {
+		return false
+	}
+	_, ok := object["items"].([]interface{})
+	return ok
+}
+
+// expandList returns items of list document, items which are lists themselves are expanded recursively. Items of typed
+// lists, such as DeploymentList, may leave apiVersion and kind out, they are set from the list.
+func expandList(document Document) []Document {
+	apiVersion, _ := document.Object["apiVersion"].(string)
+	kind := strings.TrimSuffix(document.Object["kind"].(string), "List")
+	var items []Document
+	for i, item := range document.Object["items"].([]interface{}) {
+		path := joinPath("items", strconv.Itoa(i))
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			log.Debug(fmt.Sprintf("%s: document %d: %s is not an object", document.FileName, document.Index, path))
+			continue
+		}
+		if _, ok := object["apiVersion"]; !ok && len(apiVersion) > 0 && len(kind) > 0 {
+			object["apiVersion"] = apiVersion
+		}
+		if _, ok := object["kind"]; !ok && len(kind) > 0 {
+			object["kind"] = kind
+		}
+		positions := map[string]Position{}
+		for key, position := range document.positions {
+			if strings.HasPrefix(key, path+"/") {
+				positions[strings.TrimPrefix(key, path+"/")] = position
+			}
+		}
+		itemDocument := Document{
+			FileName:     document.FileName,
+			Index:        document.Index,
+			Object:       object,
+			ListItemPath: joinPath(document.ListItemPath, path),
+			Position:     document.PositionOf(path),
+			positions:    positions,
+		}
+		if isListObject(object) {
+			items = append(items, expandList(itemDocument)...)
+		} else {
+			items = append(items, itemDocument)
+		}
+	}
+	return items
+}
+
+// PositionOf returns the position of field at path of the object, or of its closest parent if the field is not in
+// the document, eg a missing required field
+func (d *Document) PositionOf(path string) Position {
+	return positionOf(d.positions, path, d.Position)
+}
+
+// positionOf returns position of path, or of its closest parent, in positions, or position of the document if none of
+// them is known
+func positionOf(positions map[string]Position, path string, document Position) Position {
+	for {
+		if position, ok := positions[path]; ok {
+			return position
+		}
+		if len(path) == 0 {
+			return document
+		}
+		if i := strings.LastIndex(path, "/"); i >= 0 {
+			path = path[:i]
+		} else {
+			path = ""
+		}
+	}
+}
+
+// nodePositions adds positions of node and of nodes under it to positions keyed by their JSON path, offset is the
+// position of the start of the node if it is parsed on its own
+func nodePositions(node *yamlv3.Node, path string, offset Position, positions map[string]Position) {
+	for node.Kind == yamlv3.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := joinPath(path, key.Value)
+			// fields are located at their key, which is where editors point at
+			positions[keyPath] = offsetPosition(key, offset)
+			nodePositions(value, keyPath, offset, positions)
+		}
+	case yamlv3.SequenceNode:
+		for i, item := range node.Content {
+			itemPath := joinPath(path, strconv.Itoa(i))
+			positions[itemPath] = offsetPosition(item, offset)
+			nodePositions(item, itemPath, offset, positions)
+		}
+	}
+}
+
+func offsetPosition(node *yamlv3.Node, offset Position) Position {
+	if offset.Line == 0 {
+		return Position{Line: node.Line, Column: node.Column}
+	}
+	position := Position{Line: offset.Line + node.Line - 1, Column: node.Column}
+	if node.Line == 1 {
+		position.Column += offset.Column - 1
+	}
+	return position
+}
+
+func joinPath(path, field string) string {
+	if len(path) == 0 {
+		return field
+	}
+	return path + "/" + field
+}
+
+// isJSONStream tells if the stream of reader starts with a JSON object
+func isJSONStream(reader *bufio.Reader) bool {
+	for n := 1; ; n++ {
+		peek, _ := reader.Peek(n)
+		if len(peek) < n {
+			return false
+		}
+		switch peek[n-1] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '{':
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// lineCounter records offsets of line breaks of what is read through it, so that positions of byte offsets can be
+// told
+type lineCounter struct {
+	r      io.Reader
+	offset int64
+	breaks []int64
+}
+
+func (l *lineCounter) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	for i := 0; i < n; i++ {
+		if p[i] == '\n' {
+			l.breaks = append(l.breaks, l.offset+int64(i))
+		}
+	}
+	l.offset += int64(n)
+	return n, err
+}
+
+// position returns the position of byte at offset of the stream
+func (l *lineCounter) position(offset int64) Position {
+	line := sort.Search(len(l.breaks), func(i int) bool {
+		return l.breaks[i] >= offset
+	})
+	column := offset + 1
+	if line > 0 {
+		column = offset - l.breaks[line-1]
+	}
+	return Position{Line: line + 1, Column: int(column)}
+}
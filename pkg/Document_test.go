@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeDocuments(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		wantKinds     []string
+		wantPositions []Position
+		wantErr       *Position
+	}{
+		{name: "empty input"},
+		{name: "leading separator and comment after separator",
+			input:         "---\nkind: A\n--- # second\nkind: B\n",
+			wantKinds:     []string{"A", "B"},
+			wantPositions: []Position{{Line: 2, Column: 1}, {Line: 4, Column: 1}}},
+		{name: "crlf line endings", input: "kind: A\r\n---\r\nkind: B\r\n",
+			wantKinds:     []string{"A", "B"},
+			wantPositions: []Position{{Line: 1, Column: 1}, {Line: 3, Column: 1}}},
+		{name: "document end marker and empty documents", input: "kind: A\n...\n---\n# only a comment\n---\n---\nkind: B\n",
+			wantKinds:     []string{"A", "B"},
+			wantPositions: []Position{{Line: 1, Column: 1}, {Line: 7, Column: 1}}},
+		{name: "separator without trailing line break", input: "kind: A\n---",
+			wantKinds: []string{"A"}, wantPositions: []Position{{Line: 1, Column: 1}}},
+		{name: "json document", input: "{\n\t\"kind\": \"A\"\n}\n",
+			wantKinds: []string{"A"}, wantPositions: []Position{{Line: 1, Column: 1}}},
+		{name: "concatenated json documents", input: "{\"kind\": \"A\"}\n  {\"kind\": \"B\"}",
+			wantKinds:     []string{"A", "B"},
+			wantPositions: []Position{{Line: 1, Column: 1}, {Line: 2, Column: 3}}},
+		{name: "invalid yaml after valid document", input: "kind: A\n---\nspec:\n\tkind: B\n",
+			wantKinds: []string{"A"}, wantPositions: []Position{{Line: 1, Column: 1}}, wantErr: &Position{Line: 4, Column: 1}},
+		{name: "document which is not an object", input: "kind: A\n---\n- a\n",
+			wantKinds: []string{"A"}, wantPositions: []Position{{Line: 1, Column: 1}}, wantErr: &Position{Line: 3, Column: 1}},
+		{name: "invalid json", input: "{\"kind\": \"A\"}\n{\"kind\" \"B\"}",
+			wantKinds: []string{"A"}, wantPositions: []Position{{Line: 1, Column: 1}}, wantErr: &Position{Line: 2, Column: 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			documents, err := DecodeDocuments("a.yaml", []byte(tt.input))
+			var kinds []string
+			var positions []Position
+			for i, document := range documents {
+				assert.Equal(t, "a.yaml", document.FileName)
+				assert.Equal(t, i, document.Index)
+				kinds = append(kinds, document.Object["kind"].(string))
+				positions = append(positions, document.Position)
+			}
+			assert.Equal(t, tt.wantKinds, kinds)
+			assert.Equal(t, tt.wantPositions, positions)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var documentError *DocumentError
+			if assert.True(t, errors.As(err, &documentError)) {
+				assert.Equal(t, "a.yaml", documentError.FileName)
+				assert.Equal(t, len(tt.wantKinds), documentError.Index)
+				assert.Equal(t, *tt.wantErr, documentError.Position)
+			}
+		})
+	}
+}
+
+func TestDocumentPositionOf(t *testing.T) {
+	input := `# deployment
+apiVersion: apps/v1
+kind: Deployment
+spec:
+  template:
+    spec:
+      containers:
+      - name: a
+        image: a
+      - {name: b, image: b}
+`
+	jsonInput := "{\"kind\": \"A\"}\n{\"kind\": \"B\",\n \"spec\": {\"replicas\": 1}}"
+	tests := []struct {
+		name  string
+		input string
+		index int
+		path  string
+		want  Position
+	}{
+		{name: "field", input: input, path: "kind", want: Position{Line: 3, Column: 1}},
+		{name: "nested field", input: input, path: "spec/template/spec/containers/0/image", want: Position{Line: 9, Column: 9}},
+		{name: "item of sequence", input: input, path: "spec/template/spec/containers/1", want: Position{Line: 10, Column: 9}},
+		{name: "field of flow mapping", input: input, path: "spec/template/spec/containers/1/image", want: Position{Line: 10, Column: 19}},
+		{name: "missing field is at its parent", input: input, path: "spec/template/spec/containers/0/ports/0", want: Position{Line: 8, Column: 9}},
+		{name: "missing root field is at the document", input: input, path: "metadata/name", want: Position{Line: 2, Column: 1}},
+		{name: "field of json document", input: jsonInput, index: 1, path: "spec/replicas", want: Position{Line: 3, Column: 11}},
+		{name: "field of first line of json document", input: jsonInput, index: 1, path: "kind", want: Position{Line: 2, Column: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			documents, err := DecodeDocuments("", []byte(tt.input))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, documents[tt.index].PositionOf(tt.path))
+		})
+	}
+}
+
+func TestDecodeDocumentsExpandsLists(t *testing.T) {
+	input := `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Service
+  metadata: {name: a}
+- apiVersion: apps/v1
+  kind: DeploymentList
+  items:
+  - metadata: {name: b}
+    spec:
+      replicas: 1
+- not an object
+---
+apiVersion: v1
+kind: List
+items: []
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: c}
+`
+	documents, err := DecodeDocuments("", []byte(input))
+	assert.NoError(t, err)
+	type item struct {
+		APIVersion   string
+		Kind         string
+		Index        int
+		ListItemPath string
+		Position     Position
+	}
+	var items []item
+	for _, document := range documents {
+		items = append(items, item{APIVersion: document.Object["apiVersion"].(string), Kind: document.Object["kind"].(string),
+			Index: document.Index, ListItemPath: document.ListItemPath, Position: document.Position})
+	}
+	assert.Equal(t, []item{
+		{APIVersion: "v1", Kind: "Service", ListItemPath: "items/0", Position: Position{Line: 4, Column: 3}},
+		{APIVersion: "apps/v1", Kind: "Deployment", ListItemPath: "items/1/items/0", Position: Position{Line: 10, Column: 5}},
+		{APIVersion: "v1", Kind: "ConfigMap", Index: 2, Position: Position{Line: 19, Column: 1}},
+	}, items)
+	assert.Equal(t, Position{Line: 12, Column: 7}, documents[1].PositionOf("spec/replicas"))
+}
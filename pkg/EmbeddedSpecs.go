@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import "sort"
+
+// EmbeddedSpecs provides openapi specs compiled into the binary, specs are expected to be already converted to
+// openapi 3 i.e; output of ConvertOpenApi2
+type EmbeddedSpecs interface {
+	ReleaseVersions() []string
+	Load(releaseVersion string) ([]byte, error)
+}
+
+var embeddedSpecs EmbeddedSpecs
+
+// RegisterEmbeddedSpecs registers specs which are consulted before downloading specs over the network
+func RegisterEmbeddedSpecs(specs EmbeddedSpecs) {
+	embeddedSpecs = specs
+}
+
+// GetEmbeddedReleaseVersions returns sorted list of release versions bundled in the binary
+func GetEmbeddedReleaseVersions() []string {
+	if embeddedSpecs == nil {
+		return nil
+	}
+	versions := embeddedSpecs.ReleaseVersions()
+	sort.Slice(versions, func(i, j int) bool {
+		return compareReleaseVersion(versions[i], versions[j])
+	})
+	return versions
+}
+
+func hasEmbeddedReleaseVersion(releaseVersion string) bool {
+	if embeddedSpecs == nil {
+		return false
+	}
+	for _, version := range embeddedSpecs.ReleaseVersions() {
+		if version == releaseVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// ConvertOpenApi2 converts swagger 2.0 spec of a kubernetes release to the openapi 3 form used for validation
+func ConvertOpenApi2(data []byte) ([]byte, error) {
+	return NewKubeCheckerImpl().convertOpenApi2(data)
+}
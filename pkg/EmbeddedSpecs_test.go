@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/devtron-labs/silver-surfer/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEmbeddedSpecs map[string][]byte
+
+func (s testEmbeddedSpecs) ReleaseVersions() []string {
+	var versions []string
+	for version := range s {
+		versions = append(versions, version)
+	}
+	return versions
+}
+
+func (s testEmbeddedSpecs) Load(releaseVersion string) ([]byte, error) {
+	return s[releaseVersion], nil
+}
+
+func TestEmbeddedSchemaSource(t *testing.T) {
+	spec, err := ConvertOpenApi2([]byte(testSwaggerSpec))
+	assert.NoError(t, err)
+	RegisterEmbeddedSpecs(testEmbeddedSpecs{"1.22": spec, "1.9": spec, "1.10": spec})
+	defer RegisterEmbeddedSpecs(nil)
+	assert.Equal(t, []string{"1.9", "1.10", "1.22"}, GetEmbeddedReleaseVersions())
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	tests := []struct {
+		name           string
+		releaseVersion string
+		wantRequests   int
+		wantErr        bool
+	}{
+		{name: "embedded spec is loaded without downloading", releaseVersion: "1.22"},
+		{name: "missing spec falls through to schema location", releaseVersion: "1.23", wantRequests: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = 0
+			data, format, err := (&embeddedSchemaSource{}).Fetch(tt.releaseVersion)
+			if tt.wantErr {
+				assert.Equal(t, errors.ErrOpenApiSpecNotFound, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, SpecFormatOpenApi3, format)
+				assert.Equal(t, spec, data)
+			}
+			kc, err := NewKubeCheckerImplForConfig(&Config{SchemaLocation: server.URL + "/%s.json"})
+			assert.NoError(t, err)
+			err = kc.LoadFromUrl(tt.releaseVersion, false)
+			assert.Equal(t, tt.wantRequests, requests)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, kc.IsApiVersionSupported(tt.releaseVersion, "apps/v1", "Deployment"))
+		})
+	}
+}
@@ -45,6 +45,26 @@ func removeIgnoredKeys(result ValidationResult, conf *Config) ValidationResult {
 		}
 		result.DeprecationForLatest = depErr
 	}
+	if len(result.FieldRemovals) > 0 {
+		var remErr []*SchemaError
+		for _, schemaError := range result.FieldRemovals {
+			key := strings.Join(schemaError.JSONPointer(), "/")
+			if !Contains(key, conf.IgnoreKeysFromValidation) {
+				remErr = append(remErr, schemaError)
+			}
+		}
+		result.FieldRemovals = remErr
+	}
+	if len(result.UnknownFields) > 0 {
+		var unkErr []*SchemaError
+		for _, schemaError := range result.UnknownFields {
+			key := strings.Join(schemaError.JSONPointer(), "/")
+			if !Contains(key, conf.IgnoreKeysFromValidation) {
+				unkErr = append(unkErr, schemaError)
+			}
+		}
+		result.UnknownFields = unkErr
+	}
 	if len(result.ErrorsForOriginal) > 0 {
 		var valErr []*openapi3.SchemaError
 		for _, schemaError := range result.ErrorsForOriginal {
@@ -18,18 +18,21 @@
 package pkg
 
 import (
-	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/devtron-labs/silver-surfer/pkg/errors"
+	"github.com/devtron-labs/silver-surfer/pkg/log"
 	"github.com/getkin/kin-openapi/openapi2"
 	"github.com/getkin/kin-openapi/openapi2conv"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/tidwall/sjson"
-	"io"
 	"io/ioutil"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"net/http"
+	"sigs.k8s.io/yaml"
+	"sort"
+	"strings"
+	"sync"
 )
 
 const (
@@ -52,82 +55,422 @@ type Validator interface {
 type Parser interface {
 	LoadFromUrl(releaseVersion string, force bool) error
 	LoadFromPath(releaseVersion string, filePath string, force bool) error
+	LoadFromSource(releaseVersion string, source SchemaSource, force bool) error
+	AddSchemaSource(source SchemaSource)
+	AddCustomResourceDefinition(crd map[string]interface{}) error
+	SetPreferredVersions(releaseVersion string, preferredVersions map[string]string)
 }
 
 type KubeChecker interface {
 	IsApiVersionSupported(releaseVersion, apiVersion, kind string) bool
+	ResolveReleaseVersion(version string) (string, error)
+	ApiLifecycleTimeline(releaseVersions []string) (*ApiLifecycleTimeline, error)
+	DiffSchemas(fromReleaseVersion, toReleaseVersion string) (*SchemaDiff, error)
 	Parser
 	Validator
 }
 
 type kubeCheckerImpl struct {
-	versionMap map[string]*kubeSpec
+	lock             sync.RWMutex
+	versionMap       map[string]*kubeSpec
+	sources          []SchemaSource
+	openApi3Versions []string
+	crds             []*customResourceDefinition
+	// preferredVersions holds versions of api groups preferred by the api server keyed by release
+	preferredVersions map[string]map[string]string
+	registry          *KubeCheckerRegistry
+	// releaseKeys memoises keys of release versions looked up so far, see releaseKey
+	releaseKeys map[string]string
+	// previousReleases holds releases whose specs are loaded to tell removed apis, see servedInEarlierRelease
+	previousReleases map[string]bool
+	// strict tells whether fields unknown to schemas are reported
+	strict bool
+	// deprecationRules tell deprecated fields and kinds, descriptions of schemas do for those no rule applies to
+	deprecationRules *DeprecationRules
+	// customRules are checks objects must pass on top of their schemas
+	customRules *CustomRules
 }
 
+// NewKubeCheckerImpl returns checker which loads specs from embedded data, if any, and upstream kubernetes repository.
+// It panics if deprecation rules shipped with kubedd, which are embedded, are invalid.
 func NewKubeCheckerImpl() *kubeCheckerImpl {
-	return &kubeCheckerImpl{versionMap: map[string]*kubeSpec{}}
+	upstream, err := newUrlSchemaSource(urlTemplate, nil)
+	if err != nil {
+		panic(err)
+	}
+	deprecationRules, err := DefaultDeprecationRules()
+	if err != nil {
+		panic(fmt.Errorf("invalid deprecation rules shipped with kubedd: %w", err))
+	}
+	return &kubeCheckerImpl{versionMap: map[string]*kubeSpec{}, sources: []SchemaSource{&embeddedSchemaSource{}, upstream},
+		deprecationRules: deprecationRules}
+}
+
+// NewKubeCheckerImplForConfig returns checker which loads specs from the chain of schema sources described by conf,
+// downloaded specs are persisted in conf.CacheDir, if it is set
+func NewKubeCheckerImplForConfig(conf *Config) (*kubeCheckerImpl, error) {
+	sources, err := NewSchemaSourcesForConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	deprecationRules, err := LoadDeprecationRules(conf.DeprecationRules)
+	if err != nil {
+		return nil, err
+	}
+	customRules, err := LoadCustomRules(conf.RulesDir)
+	if err != nil {
+		return nil, err
+	}
+	return &kubeCheckerImpl{versionMap: map[string]*kubeSpec{}, sources: sources, openApi3Versions: conf.OpenApiV3Versions,
+		strict: conf.Strict, deprecationRules: deprecationRules, customRules: customRules}, nil
+}
+
+// useOpenApi3 tells if releaseVersion is to be loaded from per group-version openapi 3 documents
+func (k *kubeCheckerImpl) useOpenApi3(releaseVersion string) bool {
+	for _, version := range k.openApi3Versions {
+		if version == "*" || k.releaseKey(version) == releaseVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// registryKey identifies spec of releaseVersion loaded from the chain of schema sources of the checker
+func (k *kubeCheckerImpl) registryKey(releaseVersion string) string {
+	names := make([]string, 0, len(k.sources))
+	for _, source := range k.sources {
+		names = append(names, source.Name())
+	}
+	return fmt.Sprintf("%s|%t|%s", releaseVersion, k.useOpenApi3(releaseVersion), strings.Join(names, ","))
+}
+
+// AddSchemaSource appends source to the chain of sources consulted by LoadFromUrl
+func (k *kubeCheckerImpl) AddSchemaSource(source SchemaSource) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	k.sources = append(k.sources, source)
+	// versions of bundles may resolve aliases differently
+	k.releaseKeys = nil
+}
+
+// AddCustomResourceDefinition registers schemas of every version of crd with specs loaded now or later so that
+// custom resources of crd are validated
+func (k *kubeCheckerImpl) AddCustomResourceDefinition(object map[string]interface{}) error {
+	crd, err := parseCustomResourceDefinition(object)
+	if err != nil {
+		return err
+	}
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	for releaseVersion, ks := range k.versionMap {
+		// specs may be shared through the registry hence they are never modified in place
+		ks = ks.clone()
+		if err := ks.addCustomResourceDefinition(crd); err != nil {
+			return err
+		}
+		k.versionMap[releaseVersion] = ks
+	}
+	k.crds = append(k.crds, crd)
+	return nil
+}
+
+// SetPreferredVersions registers versions of api groups preferred by the api server of releaseVersion, as found
+// by discovery, so that replacement apiVersions in the release are picked as the api server would
+func (k *kubeCheckerImpl) SetPreferredVersions(releaseVersion string, preferredVersions map[string]string) {
+	releaseVersion = k.releaseKey(releaseVersion)
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if k.preferredVersions == nil {
+		k.preferredVersions = map[string]map[string]string{}
+	}
+	k.preferredVersions[releaseVersion] = preferredVersions
+	if ks, ok := k.versionMap[releaseVersion]; ok {
+		// specs may be shared through the registry hence they are never modified in place
+		ks = ks.clone()
+		ks.preferredVersions = preferredVersions
+		k.versionMap[releaseVersion] = ks
+	}
+}
+
+// ResolveReleaseVersion maps version to the kubernetes release it refers to, see ResolveReleaseVersion, aliases and
+// versions of schema bundles opened by the checker are taken into account as well
+func (k *kubeCheckerImpl) ResolveReleaseVersion(version string) (string, error) {
+	k.lock.RLock()
+	sources := append([]SchemaSource(nil), k.sources...)
+	k.lock.RUnlock()
+	versions := GetEmbeddedReleaseVersions()
+	for _, source := range sources {
+		if bundle, ok := source.(*bundleSchemaSource); ok {
+			if resolved, ok := bundle.bundle.Resolve(version); ok {
+				return resolved, nil
+			}
+			versions = append(versions, bundle.bundle.ReleaseVersions()...)
+		}
+	}
+	return ResolveReleaseVersion(version, versions)
+}
+
+// releaseKey returns the key under which spec of releaseVersion is kept, versions which can not be resolved, labels
+// of specs loaded from a path for instance, are kept as they are. Keys are resolved once per version since they are
+// looked up for every object validated.
+func (k *kubeCheckerImpl) releaseKey(releaseVersion string) string {
+	k.lock.RLock()
+	key, ok := k.releaseKeys[releaseVersion]
+	k.lock.RUnlock()
+	if ok {
+		return key
+	}
+	key = releaseVersion
+	if resolved, err := k.ResolveReleaseVersion(releaseVersion); err == nil {
+		key = resolved
+	}
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if k.releaseKeys == nil {
+		k.releaseKeys = map[string]string{}
+	}
+	k.releaseKeys[releaseVersion] = key
+	return key
+}
+
+func (k *kubeCheckerImpl) setSpec(releaseVersion string, ks *kubeSpec) error {
+	releaseVersion = k.releaseKey(releaseVersion)
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	preferredVersions, hasPreferredVersions := k.preferredVersions[releaseVersion]
+	if len(k.crds) > 0 || hasPreferredVersions {
+		ks = ks.clone()
+	}
+	if hasPreferredVersions {
+		ks.preferredVersions = preferredVersions
+	}
+	for _, crd := range k.crds {
+		if err := ks.addCustomResourceDefinition(crd); err != nil {
+			return err
+		}
+	}
+	k.versionMap[releaseVersion] = ks
+	return nil
+}
+
+func (k *kubeCheckerImpl) getSpec(releaseVersion string) (*kubeSpec, bool) {
+	releaseVersion = k.releaseKey(releaseVersion)
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	ks, ok := k.versionMap[releaseVersion]
+	return ks, ok
 }
 
 func (k *kubeCheckerImpl) hasReleaseVersion(releaseVersion string) bool {
-	_, ok := k.versionMap[releaseVersion]
+	_, ok := k.getSpec(releaseVersion)
 	return ok
 }
 
 func (k *kubeCheckerImpl) LoadFromPath(releaseVersion string, filePath string, force bool) error {
-	if _, ok := k.versionMap[releaseVersion]; ok && !force {
+	if k.hasReleaseVersion(releaseVersion) && !force {
 		return nil
 	}
-	data, err := ioutil.ReadFile(filePath)
+	var ks *kubeSpec
+	var err error
+	if isOpenApi3Dir(filePath) {
+		var docs [][]byte
+		if docs, err = readOpenApi3Dir(filePath); err != nil {
+			return err
+		}
+		ks, err = k.parseOpenApi3Docs(docs)
+	} else if IsSchemaBundle(filePath) {
+		var source SchemaSource
+		if source, err = k.openBundle(filePath); err != nil {
+			return err
+		}
+		releaseVersion = k.releaseKey(releaseVersion)
+		ks, err = k.fetchFromSource(source, releaseVersion)
+	} else {
+		var data []byte
+		if data, err = ioutil.ReadFile(filePath); err != nil {
+			//kLog.Debug(fmt.Sprintf("%v", err))
+			return err
+		}
+		if IsSchemaIndex(data) {
+			ks, err = decodeSchemaIndex(data)
+		} else {
+			ks, err = k.parseOpenApi2(data)
+		}
+	}
 	if err != nil {
-		//kLog.Debug(fmt.Sprintf("%v", err))
 		return err
 	}
-	return k.load(data, releaseVersion)
+	return k.setSpec(releaseVersion, ks)
 }
 
-func (k *kubeCheckerImpl) LoadFromUrl(releaseVersion string, force bool) error {
-	if _, ok := k.versionMap[releaseVersion]; ok && !force {
+// LoadFromSource loads spec of releaseVersion from source bypassing the chain of schema sources. Specs are shared
+// with other checkers of the registry, if the checker was created by one, for the source spec ttl of the registry.
+func (k *kubeCheckerImpl) LoadFromSource(releaseVersion string, source SchemaSource, force bool) error {
+	if k.hasReleaseVersion(releaseVersion) && !force {
 		return nil
 	}
-	data, err := k.downloadFile(releaseVersion)
+	releaseVersion = k.releaseKey(releaseVersion)
+	_, isOpenApi3 := source.(OpenApi3SchemaSource)
+	isOpenApi3 = isOpenApi3 && k.useOpenApi3(releaseVersion)
+	load := func() (*kubeSpec, error) {
+		if isOpenApi3 {
+			return k.fetchOpenApi3FromSource(source, releaseVersion)
+		}
+		return k.fetchFromSource(source, releaseVersion)
+	}
+	var ks *kubeSpec
+	var err error
+	if k.registry != nil {
+		key := fmt.Sprintf("%s|%t|source:%s", releaseVersion, isOpenApi3, source.Name())
+		if force {
+			k.registry.invalidate(key)
+		}
+		ks, err = k.registry.getSourceSpec(key, releaseVersion, load)
+	} else {
+		ks, err = load()
+	}
 	if err != nil {
-		//kLog.Debug(fmt.Sprintf("%v", err))
 		return err
 	}
-	return k.load(data, releaseVersion)
+	return k.setSpec(releaseVersion, ks)
 }
 
-func (k *kubeCheckerImpl) load(data []byte, releaseVersion string) error {
-	openapi, err := k.loadOpenApi2(data)
+// LoadFromUrl loads spec of releaseVersion from the first schema source which has it, sources which do not have the
+// release version or fail to serve it are skipped. Release versions selected for openapi 3 are loaded only from
+// sources serving per group-version openapi 3 documents. Specs are shared with other checkers of the registry, if
+// the checker was created by one.
+func (k *kubeCheckerImpl) LoadFromUrl(releaseVersion string, force bool) error {
+	if k.hasReleaseVersion(releaseVersion) && !force {
+		return nil
+	}
+	releaseVersion, err := k.ResolveReleaseVersion(releaseVersion)
 	if err != nil {
-		//kLog.Debug(fmt.Sprintf("%v", err))
 		return err
 	}
-	k.versionMap[releaseVersion] = newKubeSpec(openapi)
-	return nil
+	var ks *kubeSpec
+	if k.registry != nil {
+		key := k.registryKey(releaseVersion)
+		if force {
+			k.registry.invalidate(key)
+		}
+		ks, err = k.registry.get(key, releaseVersion, 0, func() (*kubeSpec, error) {
+			return k.fetchFromChain(releaseVersion)
+		})
+	} else {
+		ks, err = k.fetchFromChain(releaseVersion)
+	}
+	if err != nil {
+		return err
+	}
+	return k.setSpec(releaseVersion, ks)
 }
 
-func (k *kubeCheckerImpl) downloadFile(releaseVersion string) ([]byte, error) {
-	url := fmt.Sprintf(urlTemplate, releaseVersion)
-	resp, err := http.Get(url)
-	if err != nil {
+func (k *kubeCheckerImpl) fetchFromChain(releaseVersion string) (*kubeSpec, error) {
+	k.lock.RLock()
+	sources := append([]SchemaSource(nil), k.sources...)
+	k.lock.RUnlock()
+	var lastErr error
+	for _, source := range sources {
+		var ks *kubeSpec
+		var err error
+		if k.useOpenApi3(releaseVersion) {
+			ks, err = k.fetchOpenApi3FromSource(source, releaseVersion)
+		} else {
+			ks, err = k.fetchFromSource(source, releaseVersion)
+		}
+		if err == nil {
+			return ks, nil
+		}
+		if err == errors.ErrOpenApiSpecNotFound {
+			continue
+		}
 		//kLog.Debug(fmt.Sprintf("%v", err))
-		return []byte{}, err
+		lastErr = fmt.Errorf("%s: %w", source.Name(), err)
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode == http.StatusNotFound {
-		return []byte{}, errors.ErrOpenApiSpecNotFound
+	if lastErr != nil {
+		return nil, lastErr
 	}
-	var out bytes.Buffer
-	_, err = io.Copy(&out, resp.Body)
+	return nil, errors.ErrOpenApiSpecNotFound
+}
+
+func (k *kubeCheckerImpl) fetchFromSource(source SchemaSource, releaseVersion string) (*kubeSpec, error) {
+	data, format, err := source.Fetch(releaseVersion)
 	if err != nil {
-		//kLog.Debug(fmt.Sprintf("%v", err))
-		return []byte{}, err
+		return nil, err
+	}
+	if format == SpecFormatIndex || IsSchemaIndex(data) {
+		return decodeSchemaIndex(data)
+	}
+	if format == SpecFormatSwagger {
+		return k.parseOpenApi2(data)
+	}
+	return k.parseOpenApi3(data)
+}
+
+func (k *kubeCheckerImpl) fetchOpenApi3FromSource(source SchemaSource, releaseVersion string) (*kubeSpec, error) {
+	v3Source, ok := source.(OpenApi3SchemaSource)
+	if !ok {
+		return nil, errors.ErrOpenApiSpecNotFound
+	}
+	docs, err := v3Source.FetchOpenApi3(releaseVersion)
+	if err != nil {
+		return nil, err
+	}
+	return k.parseOpenApi3Docs(docs)
+}
+
+// parseOpenApi3Docs merges per group-version openapi 3 documents into one spec
+func (k *kubeCheckerImpl) parseOpenApi3Docs(docs [][]byte) (*kubeSpec, error) {
+	data, err := MergeOpenApi3(docs)
+	if err != nil {
+		return nil, err
+	}
+	return k.parseOpenApi3(data)
+}
+
+func (k *kubeCheckerImpl) parseOpenApi2(data []byte) (*kubeSpec, error) {
+	converted, err := k.convertOpenApi2(data)
+	if err != nil {
+		return nil, err
+	}
+	return k.parseOpenApi3(converted)
+}
+
+// parseOpenApi3 returns spec of data, size of data is kept as size of the spec which bounds specs of the registry
+func (k *kubeCheckerImpl) parseOpenApi3(data []byte) (*kubeSpec, error) {
+	openapi, err := k.loadOpenApi3(data)
+	if err != nil {
+		return nil, err
+	}
+	ks := newKubeSpec(openapi)
+	ks.size = int64(len(data))
+	return ks, nil
+}
+
+// openBundle opens bundle at location once and puts it ahead of other sources so that it is consulted for any
+// release version loaded later
+func (k *kubeCheckerImpl) openBundle(location string) (SchemaSource, error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	for _, source := range k.sources {
+		if bundle, ok := source.(*bundleSchemaSource); ok && bundle.Name() == location {
+			return bundle, nil
+		}
+	}
+	bundle, err := OpenSchemaBundle(location)
+	if err != nil {
+		return nil, err
 	}
-	return out.Bytes(), nil
+	source := &bundleSchemaSource{bundle: bundle}
+	k.sources = append([]SchemaSource{source}, k.sources...)
+	// versions of the bundle may resolve aliases differently
+	k.releaseKeys = nil
+	return source, nil
 }
 
-func (k *kubeCheckerImpl) loadOpenApi2(data []byte) (*openapi3.T, error) {
+// convertOpenApi2 converts swagger 2.0 spec published with kubernetes releases to openapi 3 spec, output of this
+// conversion is what loadOpenApi3 expects hence it can be persisted and loaded later without converting again
+func (k *kubeCheckerImpl) convertOpenApi2(data []byte) ([]byte, error) {
 	var err error
 	stringData := string(data)
 	stringData, err = sjson.Delete(stringData, intOrStringFormat)
@@ -168,9 +511,13 @@ func (k *kubeCheckerImpl) loadOpenApi2(data []byte) (*openapi3.T, error) {
 		//kLog.Debug(fmt.Sprintf("%v", err))
 		return nil, err
 	}
+	return []byte(stringData), nil
+}
 
+func (k *kubeCheckerImpl) loadOpenApi3(data []byte) (*openapi3.T, error) {
+	ctx := context.Background()
 	loader := &openapi3.Loader{Context: ctx}
-	doc, err = loader.LoadFromData([]byte(stringData))
+	doc, err := loader.LoadFromData(data)
 	if err != nil {
 		//kLog.Debug(fmt.Sprintf("%v", err))
 		return nil, err
@@ -181,26 +528,26 @@ func (k *kubeCheckerImpl) loadOpenApi2(data []byte) (*openapi3.T, error) {
 		//kLog.Debug(fmt.Sprintf("%v", err))
 		return nil, err
 	}
-	//for _, v := range doc.Components.Schemas {
-	//	v.Value.AdditionalPropertiesAllowed = openapi3.BoolPtr(false)
-	//}
 	return doc, nil
 }
 
 func (k *kubeCheckerImpl) ValidateYaml(spec string, releaseVersion string) (ValidationResult, error) {
-	err := k.LoadFromUrl(releaseVersion, false)
+	jsonSpec, err := yaml.YAMLToJSON([]byte(spec))
 	if err != nil {
+		log.Debug(fmt.Sprintf("%v", err))
 		return ValidationResult{}, err
 	}
-	return k.versionMap[releaseVersion].ValidateYaml(spec)
+	return k.ValidateJson(string(jsonSpec), releaseVersion)
 }
 
 func (k *kubeCheckerImpl) ValidateJson(spec string, releaseVersion string) (ValidationResult, error) {
-	err := k.LoadFromUrl(releaseVersion, false)
+	object := make(map[string]interface{})
+	err := json.Unmarshal([]byte(spec), &object)
 	if err != nil {
+		log.Debug(fmt.Sprintf("%v", err))
 		return ValidationResult{}, err
 	}
-	return k.versionMap[releaseVersion].ValidateJson(spec)
+	return k.ValidateObject(object, releaseVersion)
 }
 
 func (k *kubeCheckerImpl) ValidateObject(spec map[string]interface{}, releaseVersion string) (ValidationResult, error) {
@@ -208,7 +555,86 @@ func (k *kubeCheckerImpl) ValidateObject(spec map[string]interface{}, releaseVer
 	if err != nil {
 		return ValidationResult{}, err
 	}
-	return k.versionMap[releaseVersion].ValidateObject(spec)
+	ks, _ := k.getSpec(releaseVersion)
+	validationResult, err := ks.validateObject(spec, k.deprecationRules, k.releaseKey(releaseVersion))
+	if err != nil {
+		return validationResult, err
+	}
+	if validationResult.SchemaNotFound && k.servedInEarlierRelease(releaseVersion, validationResult.APIVersion, validationResult.Kind) {
+		//apiVersion is removed altogether eg psp
+		validationResult.SchemaNotFound = false
+		validationResult.ValidatedAgainstSchema = true
+		validationResult.Deleted = true
+		validationResult.IsVersionSupported = 2
+	}
+	k.deprecationRules.applyDeprecationRules(&validationResult, spec, k.releaseKey(releaseVersion))
+	if len(validationResult.Kind) > 0 {
+		validationResult.RuleViolations = k.customRules.violations(spec, validationResult.APIVersion, validationResult.Kind, k.releaseKey(releaseVersion))
+	}
+	if !k.strict {
+		return validationResult, nil
+	}
+	if original, _, err := ks.getKindsMappings(spec); err == nil && len(original) > 0 {
+		validationResult.UnknownFields = ks.unknownFields(spec, original)
+	}
+	return validationResult, nil
+}
+
+// servedInEarlierRelease tells if apiVersion of kind is served in an earlier release than releaseVersion, it tells
+// apis removed by releaseVersion apart from kinds its spec never knew about such as custom resources whose
+// definitions are missing. Kinds kubernetes moved or removed, see apiGroupMoves, are known to be served earlier,
+// otherwise specs of earlier releases loaded by the checker are looked up. If none is loaded, as is the case when
+// source and target releases are the same, spec of the previous minor release is loaded for it.
+func (k *kubeCheckerImpl) servedInEarlierRelease(releaseVersion, apiVersion, kind string) bool {
+	release := k.releaseKey(releaseVersion)
+	major, minor, err := parseReleaseVersion(release)
+	if err != nil {
+		return false
+	}
+	if gv, err := schema.ParseGroupVersion(apiVersion); err == nil {
+		if _, ok := apiGroupMoves[strings.ToLower(gv.Group+"/"+kind)]; ok {
+			return true
+		}
+	}
+	served, loaded := k.servedInLoadedRelease(release, apiVersion, kind)
+	if loaded || minor == 0 {
+		return served
+	}
+	previous := fmt.Sprintf("%d.%d", major, minor-1)
+	k.lock.Lock()
+	tried := k.previousReleases[previous]
+	if k.previousReleases == nil {
+		k.previousReleases = map[string]bool{}
+	}
+	k.previousReleases[previous] = true
+	k.lock.Unlock()
+	if tried {
+		return false
+	}
+	if err := k.LoadFromUrl(previous, false); err != nil {
+		log.Debug(fmt.Sprintf("unable to load openapi-spec of %s to look up %s %s: %v", previous, apiVersion, kind, err))
+		return false
+	}
+	served, _ = k.servedInLoadedRelease(release, apiVersion, kind)
+	return served
+}
+
+// servedInLoadedRelease tells if apiVersion of kind is served in spec of any loaded release earlier than release,
+// and whether spec of any earlier release is loaded at all
+func (k *kubeCheckerImpl) servedInLoadedRelease(release, apiVersion, kind string) (bool, bool) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	loaded := false
+	for key, ks := range k.versionMap {
+		if _, _, err := parseReleaseVersion(key); err != nil || !compareReleaseVersion(key, release) {
+			continue
+		}
+		loaded = true
+		if ks.isApiVersionSupported(apiVersion, kind) {
+			return true, true
+		}
+	}
+	return false, loaded
 }
 
 func (k *kubeCheckerImpl) GetKinds(releaseVersion string) ([]schema.GroupVersionKind, error) {
@@ -216,7 +642,8 @@ func (k *kubeCheckerImpl) GetKinds(releaseVersion string) ([]schema.GroupVersion
 	if err != nil {
 		return make([]schema.GroupVersionKind, 0), err
 	}
-	return k.versionMap[releaseVersion].getLatestKinds(), nil
+	ks, _ := k.getSpec(releaseVersion)
+	return ks.getLatestKinds(), nil
 }
 
 func (k *kubeCheckerImpl) IsApiVersionSupported(releaseVersion, apiVersion, kind string) bool {
@@ -224,5 +651,47 @@ func (k *kubeCheckerImpl) IsApiVersionSupported(releaseVersion, apiVersion, kind
 	if err != nil {
 		return false
 	}
-	return k.versionMap[releaseVersion].isApiVersionSupported(apiVersion, kind)
+	ks, _ := k.getSpec(releaseVersion)
+	return ks.isApiVersionSupported(apiVersion, kind)
+}
+
+// ApiLifecycleTimeline returns lifecycle of every group/version/kind served in any of releaseVersions, specs of
+// releases which are not loaded yet are loaded from the chain of schema sources
+func (k *kubeCheckerImpl) ApiLifecycleTimeline(releaseVersions []string) (*ApiLifecycleTimeline, error) {
+	releases := make([]string, 0, len(releaseVersions))
+	for _, releaseVersion := range releaseVersions {
+		release, err := k.ResolveReleaseVersion(releaseVersion)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, release)
+	}
+	sort.Slice(releases, func(i, j int) bool {
+		return compareReleaseVersion(releases[i], releases[j])
+	})
+	apis := make([][]servedApi, 0, len(releases))
+	for _, release := range releases {
+		if err := k.LoadFromUrl(release, false); err != nil {
+			return nil, err
+		}
+		ks, _ := k.getSpec(release)
+		apis = append(apis, ks.servedApis(k.deprecationRules, release))
+	}
+	return newApiLifecycleTimeline(releases, apis), nil
+}
+
+// DiffSchemas compares group/version/kinds served in fromReleaseVersion and their schemas with those of
+// toReleaseVersion, specs of releases which are not loaded yet are loaded from the chain of schema sources
+func (k *kubeCheckerImpl) DiffSchemas(fromReleaseVersion, toReleaseVersion string) (*SchemaDiff, error) {
+	specs := make([]*kubeSpec, 0, 2)
+	releases := make([]string, 0, 2)
+	for _, releaseVersion := range []string{fromReleaseVersion, toReleaseVersion} {
+		if err := k.LoadFromUrl(releaseVersion, false); err != nil {
+			return nil, err
+		}
+		ks, _ := k.getSpec(releaseVersion)
+		specs = append(specs, ks)
+		releases = append(releases, k.releaseKey(releaseVersion))
+	}
+	return diffKubeSpecs(releases[0], specs[0], releases[1], specs[1]), nil
 }
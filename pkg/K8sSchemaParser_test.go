@@ -18,7 +18,13 @@
 package pkg
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 const deployment = `
@@ -391,6 +397,94 @@ func TestDownloadFile(t *testing.T) {
 	}
 }
 
+func TestValidateObjectSchemaNotFound(t *testing.T) {
+	dir := t.TempDir()
+	specFile := filepath.Join(dir, "swagger.json")
+	assert.NoError(t, ioutil.WriteFile(specFile, []byte(testSwaggerSpec), 0644))
+	// specs of earlier releases serving FlowSchema of a group version removed by 1.22
+	earlierSpec := func(release string) []byte {
+		return []byte(strings.NewReplacer(`"group": "apps"`, `"group": "flowcontrol.apiserver.k8s.io"`, `"version": "v1"`, `"version": "v1alpha1"`,
+			`"kind": "Deployment"`, `"kind": "FlowSchema"`, "v1.22.0", release).Replace(testSwaggerSpec))
+	}
+	earlierSpecFile := filepath.Join(dir, "earlier.json")
+	assert.NoError(t, ioutil.WriteFile(earlierSpecFile, earlierSpec("v1.15.0"), 0644))
+	previousDir := t.TempDir()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(previousDir, "1.21.json"), earlierSpec("v1.21.0"), 0644))
+
+	tests := []struct {
+		name   string
+		object string
+		// earlierRelease tells whether spec of the earlier release is loaded along with that of 1.22
+		earlierRelease bool
+		// previousRelease tells whether spec of 1.21 is available at the schema location
+		previousRelease    bool
+		wantSchemaNotFound bool
+		wantDeleted        bool
+	}{
+		{
+			name:   "kind of the spec",
+			object: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "d"}, "spec": {"selector": {}}}`,
+		},
+		{
+			name:               "custom resource without its definition",
+			object:             `{"apiVersion": "example.com/v1", "kind": "Gizmo", "metadata": {"name": "g"}}`,
+			previousRelease:    true,
+			wantSchemaNotFound: true,
+		},
+		{
+			name:               "custom resource of a k8s.io group without its definition",
+			object:             `{"apiVersion": "gateway.networking.k8s.io/v1", "kind": "HTTPRoute", "metadata": {"name": "r"}}`,
+			earlierRelease:     true,
+			wantSchemaNotFound: true,
+		},
+		{
+			name:               "unknown kind of a served kubernetes group version",
+			object:             `{"apiVersion": "apps/v1", "kind": "Deploymnt", "metadata": {"name": "d"}}`,
+			wantSchemaNotFound: true,
+		},
+		{
+			name:           "group version served in an earlier release",
+			object:         `{"apiVersion": "flowcontrol.apiserver.k8s.io/v1alpha1", "kind": "FlowSchema", "metadata": {"name": "f"}}`,
+			earlierRelease: true,
+			wantDeleted:    true,
+		},
+		{
+			name:            "group version served in the previous release when no earlier release is loaded",
+			object:          `{"apiVersion": "flowcontrol.apiserver.k8s.io/v1alpha1", "kind": "FlowSchema", "metadata": {"name": "f"}}`,
+			previousRelease: true,
+			wantDeleted:     true,
+		},
+		{
+			name:               "group version unknown to every release",
+			object:             `{"apiVersion": "flowcontrol.apiserver.k8s.io/v1alpha1", "kind": "FlowSchema", "metadata": {"name": "f"}}`,
+			wantSchemaNotFound: true,
+		},
+		{
+			name:        "kind removed by kubernetes when no earlier release is loaded",
+			object:      `{"apiVersion": "policy/v1beta1", "kind": "PodSecurityPolicy", "metadata": {"name": "p"}}`,
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kc := NewKubeCheckerImpl()
+			kc.sources = []SchemaSource{&fileSchemaSource{location: t.TempDir()}}
+			if tt.previousRelease {
+				kc.sources = []SchemaSource{&fileSchemaSource{location: previousDir}}
+			}
+			assert.NoError(t, kc.LoadFromPath("1.22", specFile, false))
+			if tt.earlierRelease {
+				assert.NoError(t, kc.LoadFromPath("1.15", earlierSpecFile, false))
+			}
+			result, err := kc.ValidateJson(tt.object, "1.22")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSchemaNotFound, result.SchemaNotFound)
+			assert.Equal(t, tt.wantDeleted, result.Deleted)
+			assert.Equal(t, !tt.wantSchemaNotFound, result.ValidatedAgainstSchema)
+		})
+	}
+}
+
 func Test_compareVersion(t *testing.T) {
 	type args struct {
 		first  string
@@ -475,3 +569,58 @@ func Test_compareVersion(t *testing.T) {
 		})
 	}
 }
+
+// benchmarkSwaggerSpec returns swagger.json at KUBEDD_BENCHMARK_SPEC, if set, so that loading of a complete
+// kubernetes release can be measured, otherwise the trimmed down testSwaggerSpec
+func benchmarkSwaggerSpec(b *testing.B) []byte {
+	location := os.Getenv("KUBEDD_BENCHMARK_SPEC")
+	if len(location) == 0 {
+		return []byte(testSwaggerSpec)
+	}
+	data, err := ioutil.ReadFile(location)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return data
+}
+
+func BenchmarkLoadOpenApi2(b *testing.B) {
+	data := benchmarkSwaggerSpec(b)
+	kc := NewKubeCheckerImpl()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := kc.parseOpenApi2(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLoadOpenApi3(b *testing.B) {
+	data, err := ConvertOpenApi2(benchmarkSwaggerSpec(b))
+	if err != nil {
+		b.Fatal(err)
+	}
+	kc := NewKubeCheckerImpl()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := kc.parseOpenApi3(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLoadSchemaIndex(b *testing.B) {
+	data, err := CompileSchemaIndex(benchmarkSwaggerSpec(b), SpecFormatSwagger)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := decodeSchemaIndex(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
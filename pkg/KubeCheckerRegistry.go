@@ -0,0 +1,212 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"container/list"
+	"github.com/prometheus/client_golang/prometheus"
+	"sync"
+	"time"
+)
+
+var (
+	registryHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubedd_schema_registry_hits_total",
+		Help: "Number of spec lookups served by the schema registry without loading the spec",
+	}, []string{"release_version"})
+	registryMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubedd_schema_registry_misses_total",
+		Help: "Number of spec lookups which loaded the spec from schema sources",
+	}, []string{"release_version"})
+	registryEvictions = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kubedd_schema_registry_evictions_total",
+		Help: "Number of specs evicted from the schema registry to stay within its memory budget or once expired",
+	})
+	registryMemory = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kubedd_schema_registry_memory_bytes",
+		Help: "Size in bytes of openapi 3 documents of specs held by the schema registry",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(registryHits, registryMisses, registryEvictions, registryMemory)
+}
+
+// DefaultSourceSpecTTL is the duration for which specs loaded from a specific source, the api server of a cluster
+// for instance, are shared before they are loaded again
+const DefaultSourceSpecTTL = 10 * time.Minute
+
+// KubeCheckerRegistry shares parsed specs between checkers it creates and is safe for concurrent use. A spec is
+// loaded once even if many goroutines ask for it at the same time, least recently used specs are evicted once their
+// size exceeds the memory budget. Size of a spec is the size of the openapi 3 document it is parsed from, parsed
+// specs take a few times as much memory, the budget is to be set accordingly.
+type KubeCheckerRegistry struct {
+	lock         sync.Mutex
+	memoryBudget int64
+	memoryUsed   int64
+	sourceTTL    time.Duration
+	entries      map[string]*list.Element
+	lru          *list.List
+	loading      map[string]*specLoad
+}
+
+type registryEntry struct {
+	key string
+	ks  *kubeSpec
+	// expiresAt is the time after which the spec is loaded again, zero for specs which do not expire
+	expiresAt time.Time
+}
+
+// specLoad is a load of spec in progress which is awaited by concurrent lookups of the same spec
+type specLoad struct {
+	done chan struct{}
+	ks   *kubeSpec
+	err  error
+}
+
+// NewKubeCheckerRegistry returns registry holding specs of size within memoryBudget bytes, zero or negative budget
+// means no limit. Most recently used spec is kept even if it alone exceeds the budget.
+func NewKubeCheckerRegistry(memoryBudget int64) *KubeCheckerRegistry {
+	return &KubeCheckerRegistry{
+		memoryBudget: memoryBudget,
+		sourceTTL:    DefaultSourceSpecTTL,
+		entries:      map[string]*list.Element{},
+		lru:          list.New(),
+		loading:      map[string]*specLoad{},
+	}
+}
+
+// NewKubeChecker returns checker for conf whose specs loaded from schema sources are shared through the registry
+func (r *KubeCheckerRegistry) NewKubeChecker(conf *Config) (KubeChecker, error) {
+	k, err := NewKubeCheckerImplForConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	k.registry = r
+	return k, nil
+}
+
+// SetSourceSpecTTL sets duration for which specs loaded from a specific source, see KubeChecker.LoadFromSource, are
+// shared, zero or negative ttl means they are not shared at all
+func (r *KubeCheckerRegistry) SetSourceSpecTTL(ttl time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.sourceTTL = ttl
+}
+
+// Len returns number of specs held by the registry
+func (r *KubeCheckerRegistry) Len() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.lru.Len()
+}
+
+// MemoryUsed returns size of specs held by the registry
+func (r *KubeCheckerRegistry) MemoryUsed() int64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.memoryUsed
+}
+
+// get returns spec of key, it is loaded using load unless it is held by the registry or being loaded already. Spec
+// is held for ttl, if it is positive, or until it is evicted otherwise.
+func (r *KubeCheckerRegistry) get(key, releaseVersion string, ttl time.Duration, load func() (*kubeSpec, error)) (*kubeSpec, error) {
+	r.lock.Lock()
+	if element, ok := r.entries[key]; ok && element.Value.(*registryEntry).expired() {
+		r.remove(element)
+		registryEvictions.Inc()
+		registryMemory.Set(float64(r.memoryUsed))
+	} else if ok {
+		r.lru.MoveToFront(element)
+		r.lock.Unlock()
+		registryHits.WithLabelValues(releaseVersion).Inc()
+		return element.Value.(*registryEntry).ks, nil
+	}
+	if inProgress, ok := r.loading[key]; ok {
+		r.lock.Unlock()
+		<-inProgress.done
+		registryHits.WithLabelValues(releaseVersion).Inc()
+		return inProgress.ks, inProgress.err
+	}
+	inProgress := &specLoad{done: make(chan struct{})}
+	r.loading[key] = inProgress
+	r.lock.Unlock()
+	registryMisses.WithLabelValues(releaseVersion).Inc()
+
+	inProgress.ks, inProgress.err = load()
+
+	r.lock.Lock()
+	delete(r.loading, key)
+	if inProgress.err == nil {
+		r.add(key, inProgress.ks, ttl)
+	}
+	r.lock.Unlock()
+	close(inProgress.done)
+	return inProgress.ks, inProgress.err
+}
+
+// add puts ks at front of the lru list and evicts least recently used specs beyond memory budget, lock is held by
+// the caller
+func (r *KubeCheckerRegistry) add(key string, ks *kubeSpec, ttl time.Duration) {
+	if element, ok := r.entries[key]; ok {
+		r.remove(element)
+	}
+	entry := &registryEntry{key: key, ks: ks}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	r.entries[key] = r.lru.PushFront(entry)
+	r.memoryUsed += ks.size
+	for r.memoryBudget > 0 && r.memoryUsed > r.memoryBudget && r.lru.Len() > 1 {
+		r.remove(r.lru.Back())
+		registryEvictions.Inc()
+	}
+	registryMemory.Set(float64(r.memoryUsed))
+}
+
+func (e *registryEntry) expired() bool {
+	return !e.expiresAt.IsZero() && time.Now().After(e.expiresAt)
+}
+
+func (r *KubeCheckerRegistry) remove(element *list.Element) {
+	entry := r.lru.Remove(element).(*registryEntry)
+	delete(r.entries, entry.key)
+	r.memoryUsed -= entry.ks.size
+}
+
+// invalidate drops spec of key so that it is loaded again on next lookup
+func (r *KubeCheckerRegistry) invalidate(key string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if element, ok := r.entries[key]; ok {
+		r.remove(element)
+		registryMemory.Set(float64(r.memoryUsed))
+	}
+}
+
+// getSourceSpec returns spec of key loaded from a specific source, it is shared for sourceTTL only as the source may
+// change, an api server being upgraded for instance
+func (r *KubeCheckerRegistry) getSourceSpec(key, releaseVersion string, load func() (*kubeSpec, error)) (*kubeSpec, error) {
+	r.lock.Lock()
+	ttl := r.sourceTTL
+	r.lock.Unlock()
+	if ttl <= 0 {
+		return load()
+	}
+	return r.get(key, releaseVersion, ttl, load)
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+// newCountingSpecServer serves testSwaggerSpec for every release and counts the requests
+func newCountingSpecServer(requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		w.Write([]byte(testSwaggerSpec))
+	}))
+}
+
+// countingSchemaSource serves testSwaggerSpec for every release and counts the fetches
+type countingSchemaSource struct {
+	fetches int32
+}
+
+func (s *countingSchemaSource) Name() string {
+	return "counting"
+}
+
+func (s *countingSchemaSource) Fetch(releaseVersion string) ([]byte, string, error) {
+	atomic.AddInt32(&s.fetches, 1)
+	return []byte(testSwaggerSpec), SpecFormatSwagger, nil
+}
+
+func TestKubeCheckerRegistry(t *testing.T) {
+	var requests int32
+	server := newCountingSpecServer(&requests)
+	defer server.Close()
+	conf := &Config{SchemaLocation: server.URL + "/release-%s/swagger.json"}
+
+	t.Run("concurrent checkers load spec once", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		registry := NewKubeCheckerRegistry(0)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				kc, err := registry.NewKubeChecker(conf)
+				assert.NoError(t, err)
+				assert.NoError(t, kc.LoadFromUrl("1.22", false))
+				assert.True(t, kc.IsApiVersionSupported("1.22", "apps/v1", "Deployment"))
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+		assert.Equal(t, 1, registry.Len())
+		assert.Greater(t, registry.MemoryUsed(), int64(0))
+	})
+
+	t.Run("least recently used spec is evicted beyond memory budget", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		registry := NewKubeCheckerRegistry(1)
+		kc, err := registry.NewKubeChecker(conf)
+		assert.NoError(t, err)
+		assert.NoError(t, kc.LoadFromUrl("1.21", false))
+		assert.NoError(t, kc.LoadFromUrl("1.22", false))
+		assert.Equal(t, 1, registry.Len())
+		used := registry.MemoryUsed()
+
+		// evicted spec is loaded again by a new checker
+		kc, err = registry.NewKubeChecker(conf)
+		assert.NoError(t, err)
+		assert.NoError(t, kc.LoadFromUrl("1.22", false))
+		assert.NoError(t, kc.LoadFromUrl("1.21", false))
+		assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+		assert.Equal(t, 1, registry.Len())
+		assert.Equal(t, used, registry.MemoryUsed())
+	})
+
+	t.Run("custom resource definitions do not leak into shared spec", func(t *testing.T) {
+		crd := map[string]interface{}{}
+		assert.NoError(t, yaml.Unmarshal([]byte(testWidgetCRD), &crd))
+		registry := NewKubeCheckerRegistry(0)
+
+		withCRD, err := registry.NewKubeChecker(conf)
+		assert.NoError(t, err)
+		assert.NoError(t, withCRD.AddCustomResourceDefinition(crd))
+		assert.NoError(t, withCRD.LoadFromUrl("1.22", false))
+		assert.True(t, withCRD.IsApiVersionSupported("1.22", "example.com/v1", "Widget"))
+
+		withoutCRD, err := registry.NewKubeChecker(conf)
+		assert.NoError(t, err)
+		assert.NoError(t, withoutCRD.LoadFromUrl("1.22", false))
+		assert.False(t, withoutCRD.IsApiVersionSupported("1.22", "example.com/v1", "Widget"))
+		assert.True(t, withoutCRD.IsApiVersionSupported("1.22", "apps/v1", "Deployment"))
+	})
+
+	t.Run("specs of a source are shared until they expire", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			ttl         time.Duration
+			wait        time.Duration
+			wantFetches int32
+		}{
+			{name: "shared within ttl", ttl: time.Minute, wantFetches: 1},
+			{name: "loaded again once expired", ttl: time.Millisecond, wait: 10 * time.Millisecond, wantFetches: 2},
+			{name: "not shared without ttl", wantFetches: 2},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				registry := NewKubeCheckerRegistry(0)
+				registry.SetSourceSpecTTL(tt.ttl)
+				source := &countingSchemaSource{}
+				for i := 0; i < 2; i++ {
+					time.Sleep(tt.wait)
+					kc, err := registry.NewKubeChecker(conf)
+					assert.NoError(t, err)
+					assert.NoError(t, kc.LoadFromSource("1.22", source, false))
+					assert.True(t, kc.IsApiVersionSupported("1.22", "apps/v1", "Deployment"))
+				}
+				assert.Equal(t, tt.wantFetches, atomic.LoadInt32(&source.fetches))
+			})
+		}
+	})
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ManifestFile is a file holding a stream of YAML or JSON manifests
+type ManifestFile struct {
+	// Name is the name results of the manifests are reported with
+	Name     string
+	Contents []byte
+}
+
+// IsManifestFile tells if name is a YAML or JSON file
+func IsManifestFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// IsManifestArchive tells if name is a tar, tar.gz or zip archive, whose manifest files are read in memory
+func IsManifestArchive(name string) bool {
+	name = strings.ToLower(name)
+	for _, suffix := range []string{".tar", ".tar.gz", ".tgz", ".zip"} {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadManifestArchive returns manifest files of the tar, tar.gz or zip archive at location in the order of the
+// archive. Files are named as if the archive is a directory, eg manifests.tar.gz/app/deployment.yaml, and skipped if
+// ignored tells so by their name.
+func ReadManifestArchive(location string, ignored func(name string) (bool, error)) ([]ManifestFile, error) {
+	var files []ManifestFile
+	visit := func(name string, data []byte) error {
+		name = filepath.Join(location, filepath.FromSlash(name))
+		if !IsManifestFile(name) {
+			return nil
+		}
+		if skip, err := ignored(name); err != nil || skip {
+			return err
+		}
+		files = append(files, ManifestFile{Name: name, Contents: data})
+		return nil
+	}
+	var err error
+	if strings.HasSuffix(strings.ToLower(location), ".zip") {
+		err = readZip(location, visit)
+	} else {
+		err = readTarFile(location, visit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read archive %s: %w", location, err)
+	}
+	return files, nil
+}
+
+// readTarFile reads the tar archive at location, decompressing it if it is a tar.gz archive
+func readTarFile(location string, visit func(name string, data []byte) error) error {
+	f, err := os.Open(location)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if !strings.HasSuffix(strings.ToLower(location), ".tar") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+	return readTar(r, visit)
+}
+
+func readZip(location string, visit func(name string, data []byte) error) error {
+	zr, err := zip.OpenReader(location)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	for _, file := range zr.File {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+		if err := visit(path.Clean(strings.TrimPrefix(file.Name, "./")), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testArchiveFiles = []struct {
+	name     string
+	contents string
+}{
+	{name: "app/deployment.yaml", contents: "kind: Deployment"},
+	{name: "app/README.md", contents: "# app"},
+	{name: "./app/service.json", contents: `{"kind": "Service"}`},
+	{name: "test/pod.yml", contents: "kind: Pod"},
+}
+
+func writeTestArchive(t *testing.T, location string) {
+	var buf bytes.Buffer
+	if strings.HasSuffix(location, ".zip") {
+		zw := zip.NewWriter(&buf)
+		for _, file := range testArchiveFiles {
+			w, err := zw.Create(file.name)
+			assert.NoError(t, err)
+			_, err = w.Write([]byte(file.contents))
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, zw.Close())
+	} else {
+		var w io.Writer = &buf
+		gz := gzip.NewWriter(&buf)
+		if strings.HasSuffix(location, ".tar.gz") {
+			w = gz
+		}
+		tw := tar.NewWriter(w)
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: "app/", Mode: 0755, Typeflag: tar.TypeDir}))
+		for _, file := range testArchiveFiles {
+			assert.NoError(t, tw.WriteHeader(&tar.Header{Name: file.name, Mode: 0644, Size: int64(len(file.contents)), Typeflag: tar.TypeReg}))
+			_, err := tw.Write([]byte(file.contents))
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, tw.Close())
+		if w == gz {
+			assert.NoError(t, gz.Close())
+		}
+	}
+	assert.NoError(t, ioutil.WriteFile(location, buf.Bytes(), 0644))
+}
+
+func TestReadManifestArchive(t *testing.T) {
+	tests := []struct {
+		name      string
+		archive   string
+		ignored   string
+		wantNames []string
+	}{
+		{name: "tar archive", archive: "manifests.tar",
+			wantNames: []string{"app/deployment.yaml", "app/service.json", "test/pod.yml"}},
+		{name: "tar.gz archive", archive: "manifests.tar.gz",
+			wantNames: []string{"app/deployment.yaml", "app/service.json", "test/pod.yml"}},
+		{name: "zip archive", archive: "manifests.zip",
+			wantNames: []string{"app/deployment.yaml", "app/service.json", "test/pod.yml"}},
+		{name: "ignored files", archive: "manifests.tar.gz", ignored: "/test/",
+			wantNames: []string{"app/deployment.yaml", "app/service.json"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location := filepath.Join(t.TempDir(), tt.archive)
+			writeTestArchive(t, location)
+			files, err := ReadManifestArchive(location, func(name string) (bool, error) {
+				return len(tt.ignored) > 0 && strings.Contains(filepath.ToSlash(name), tt.ignored), nil
+			})
+			assert.NoError(t, err)
+			var names []string
+			for _, file := range files {
+				name, err := filepath.Rel(location, file.Name)
+				assert.NoError(t, err)
+				names = append(names, filepath.ToSlash(name))
+				assert.NotEmpty(t, file.Contents)
+			}
+			assert.Equal(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestReadManifestArchiveInvalid(t *testing.T) {
+	location := filepath.Join(t.TempDir(), "manifests.tar.gz")
+	assert.NoError(t, ioutil.WriteFile(location, []byte("kind: Deployment"), 0644))
+	_, err := ReadManifestArchive(location, func(string) (bool, error) { return false, nil })
+	assert.Error(t, err)
+}
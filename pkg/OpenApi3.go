@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/devtron-labs/silver-surfer/pkg/errors"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// openApi3DocSuffix is the suffix of per group-version documents in api/openapi-spec/v3 of kubernetes repository
+const openApi3DocSuffix = "_openapi.json"
+
+// OpenApi3SchemaSource is implemented by schema sources which serve per group-version openapi 3 documents published
+// by kubernetes, they carry defaults, nullable, enums and x-kubernetes-validations which are lost in swagger 2.0
+type OpenApi3SchemaSource interface {
+	FetchOpenApi3(releaseVersion string) ([][]byte, error)
+}
+
+// MergeOpenApi3 merges per group-version openapi 3 documents into one, first definition of a path or component
+// schema wins
+func MergeOpenApi3(docs [][]byte) ([]byte, error) {
+	if len(docs) == 0 {
+		return nil, errors.ErrOpenApiSpecNotFound
+	}
+	spec := map[string]interface{}{}
+	for _, doc := range docs {
+		groupSpec := map[string]interface{}{}
+		if err := json.Unmarshal(doc, &groupSpec); err != nil {
+			return nil, err
+		}
+		if len(spec) == 0 {
+			spec = groupSpec
+			continue
+		}
+		mergeOpenApi3(spec, groupSpec)
+	}
+	return json.Marshal(spec)
+}
+
+// mergeOpenApi3 adds paths and component schemas of src which are missing in dst
+func mergeOpenApi3(dst, src map[string]interface{}) {
+	mergeMissing := func(dst, src map[string]interface{}, key string) {
+		from, ok := src[key].(map[string]interface{})
+		if !ok {
+			return
+		}
+		to, ok := dst[key].(map[string]interface{})
+		if !ok {
+			to = map[string]interface{}{}
+			dst[key] = to
+		}
+		for name, value := range from {
+			if _, ok := to[name]; !ok {
+				to[name] = value
+			}
+		}
+	}
+	mergeMissing(dst, src, "paths")
+	if components, ok := src["components"].(map[string]interface{}); ok {
+		if _, ok := dst["components"].(map[string]interface{}); !ok {
+			dst["components"] = map[string]interface{}{}
+		}
+		mergeMissing(dst["components"].(map[string]interface{}), components, "schemas")
+	}
+}
+
+// isOpenApi3Dir tells if dir holds per group-version openapi 3 documents, like api/openapi-spec/v3 of kubernetes
+func isOpenApi3Dir(dir string) bool {
+	files, _ := filepath.Glob(filepath.Join(dir, "*"+openApi3DocSuffix))
+	return len(files) > 0
+}
+
+// readOpenApi3Dir reads group-version documents of dir, documents of api roots and groups are skipped as they
+// describe discovery endpoints only
+func readOpenApi3Dir(dir string) ([][]byte, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+openApi3DocSuffix))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	var docs [][]byte
+	for _, file := range files {
+		path := strings.ReplaceAll(strings.TrimSuffix(filepath.Base(file), openApi3DocSuffix), "__", "/")
+		if !isGroupVersionPath(path) {
+			continue
+		}
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, data)
+	}
+	if len(docs) == 0 {
+		return nil, errors.ErrOpenApiSpecNotFound
+	}
+	return docs, nil
+}
+
+// isGroupVersionPath tells if path, as listed by /openapi/v3, is of a group-version i.e; api/v1 or apis/<group>/<version>
+func isGroupVersionPath(path string) bool {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	return (len(parts) == 2 && parts[0] == "api") || (len(parts) == 3 && parts[0] == "apis")
+}
+
+// openApi3DocName returns name of the document of group-version path in api/openapi-spec/v3 of kubernetes repository
+func openApi3DocName(path string) string {
+	return strings.ReplaceAll(strings.Trim(path, "/"), "/", "__") + openApi3DocSuffix
+}
+
+// groupVersionPaths lists group-versions of rest paths of a swagger 2.0 or openapi 3 spec
+func groupVersionPaths(data []byte) ([]string, error) {
+	spec := struct {
+		Paths map[string]json.RawMessage `json:"paths"`
+	}{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("unable to read paths of openapi-spec: %w", err)
+	}
+	seen := map[string]bool{}
+	var paths []string
+	for restPath := range spec.Paths {
+		parts := strings.Split(strings.Trim(restPath, "/"), "/")
+		var path string
+		if len(parts) >= 2 && parts[0] == "api" {
+			path = strings.Join(parts[:2], "/")
+		} else if len(parts) >= 3 && parts[0] == "apis" {
+			path = strings.Join(parts[:3], "/")
+		}
+		if len(path) == 0 || seen[path] {
+			continue
+		}
+		seen[path] = true
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
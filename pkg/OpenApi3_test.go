@@ -0,0 +1,241 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testDeploymentOpenApi3Spec is a trimmed down api/openapi-spec/v3/apis__apps__v1_openapi.json, unlike swagger.json
+// it restricts strategy type to an enum
+const testDeploymentOpenApi3Spec = `
+{
+  "openapi": "3.0.0",
+  "info": {"title": "Kubernetes", "version": "unversioned"},
+  "paths": {
+    "/apis/apps/v1/namespaces/{namespace}/deployments": {
+      "parameters": [{"name": "namespace", "in": "path", "required": true, "schema": {"type": "string"}}],
+      "post": {
+        "operationId": "createAppsV1NamespacedDeployment",
+        "x-kubernetes-group-version-kind": {"group": "apps", "kind": "Deployment", "version": "v1"},
+        "responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/io.k8s.api.apps.v1.Deployment"}}}}}
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "io.k8s.api.apps.v1.Deployment": {
+        "type": "object",
+        "properties": {
+          "apiVersion": {"type": "string"},
+          "kind": {"type": "string"},
+          "metadata": {"type": "object"},
+          "spec": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec"}], "default": {}}
+        },
+        "x-kubernetes-group-version-kind": [{"group": "apps", "kind": "Deployment", "version": "v1"}]
+      },
+      "io.k8s.api.apps.v1.DeploymentSpec": {
+        "type": "object",
+        "required": ["selector"],
+        "properties": {
+          "replicas": {"type": "integer", "format": "int32"},
+          "selector": {"type": "object"},
+          "strategy": {
+            "type": "object",
+            "properties": {"type": {"type": "string", "enum": ["Recreate", "RollingUpdate"]}}
+          }
+        }
+      }
+    }
+  }
+}`
+
+const testDeploymentWithInvalidStrategy = `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "app"},
+"spec": {"selector": {}, "strategy": {"type": "BlueGreen"}}}`
+
+func TestMergeOpenApi3(t *testing.T) {
+	merged, err := MergeOpenApi3([][]byte{[]byte(testDeploymentOpenApi3Spec), []byte(testWidgetOpenApi3Spec)})
+	assert.NoError(t, err)
+	paths, err := groupVersionPaths(merged)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"apis/apps/v1", "apis/example.com/v1"}, paths)
+	kc := NewKubeCheckerImpl()
+	openapi, err := kc.loadOpenApi3(merged)
+	assert.NoError(t, err)
+	assert.Contains(t, openapi.Components.Schemas, "io.k8s.api.apps.v1.Deployment")
+	assert.Contains(t, openapi.Components.Schemas, "com.example.v1.Widget")
+
+	_, err = MergeOpenApi3(nil)
+	assert.Error(t, err)
+}
+
+func TestOpenApi3Sources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/release-1.29/api/openapi-spec/swagger.json":
+			w.Write([]byte(testSwaggerSpec))
+		case "/release-1.29/api/openapi-spec/v3/apis__apps__v1_openapi.json":
+			w.Write([]byte(testDeploymentOpenApi3Spec))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+	v3Dir := filepath.Join(dir, "1.29", "v3")
+	assert.NoError(t, os.MkdirAll(v3Dir, 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(v3Dir, "apis__apps__v1_openapi.json"), []byte(testDeploymentOpenApi3Spec), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(v3Dir, "apis__apps_openapi.json"), []byte(`{}`), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "1.29", "swagger.json"), []byte(testSwaggerSpec), 0644))
+
+	tests := []struct {
+		name              string
+		conf              *Config
+		filePath          string
+		wantStrategyError bool
+	}{
+		{
+			name:              "url source with openapi v3",
+			conf:              &Config{SchemaLocation: server.URL, OpenApiV3Versions: []string{"1.29"}},
+			wantStrategyError: true,
+		},
+		{
+			name:              "url source with openapi v3 cached",
+			conf:              &Config{SchemaLocation: server.URL, OpenApiV3Versions: []string{"*"}, CacheDir: filepath.Join(dir, "cache")},
+			wantStrategyError: true,
+		},
+		{
+			name:              "url source with swagger",
+			conf:              &Config{SchemaLocation: server.URL, OpenApiV3Versions: []string{"1.28"}},
+			wantStrategyError: false,
+		},
+		{
+			name:              "directory source with openapi v3",
+			conf:              &Config{SchemaLocation: dir, OpenApiV3Versions: []string{"1.29"}},
+			wantStrategyError: true,
+		},
+		{
+			name:              "openapi v3 directory as path",
+			conf:              &Config{},
+			filePath:          v3Dir,
+			wantStrategyError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kc, err := NewKubeCheckerImplForConfig(tt.conf)
+			assert.NoError(t, err)
+			if len(tt.filePath) > 0 {
+				assert.NoError(t, kc.LoadFromPath("1.29", tt.filePath, false))
+			} else {
+				assert.NoError(t, kc.LoadFromUrl("1.29", false))
+			}
+			assert.True(t, kc.IsApiVersionSupported("1.29", "apps/v1", "Deployment"))
+			result, err := kc.ValidateJson(testDeploymentWithInvalidStrategy, "1.29")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStrategyError, len(result.ErrorsForOriginal) > 0)
+		})
+	}
+}
+
+// testDeprecatedFieldOpenApi3Spec holds a deprecated field nested below references wrapped in allOf, as per
+// group-version openapi 3 documents wrap every reference of a field
+const testDeprecatedFieldOpenApi3Spec = `
+{
+  "openapi": "3.0.0",
+  "info": {"title": "Kubernetes", "version": "unversioned"},
+  "paths": {
+    "/apis/apps/v1/namespaces/{namespace}/deployments": {
+      "parameters": [{"name": "namespace", "in": "path", "required": true, "schema": {"type": "string"}}],
+      "post": {
+        "operationId": "createAppsV1NamespacedDeployment",
+        "x-kubernetes-group-version-kind": {"group": "apps", "kind": "Deployment", "version": "v1"},
+        "responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/io.k8s.api.apps.v1.Deployment"}}}}}
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "io.k8s.api.apps.v1.Deployment": {
+        "type": "object",
+        "properties": {
+          "apiVersion": {"type": "string"},
+          "kind": {"type": "string"},
+          "metadata": {"type": "object"},
+          "spec": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec"}], "default": {}}
+        },
+        "x-kubernetes-group-version-kind": [{"group": "apps", "kind": "Deployment", "version": "v1"}]
+      },
+      "io.k8s.api.apps.v1.DeploymentSpec": {
+        "type": "object",
+        "properties": {
+          "replicas": {"type": "integer", "format": "int32"},
+          "strategy": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.apps.v1.DeploymentStrategy"}], "description": "The deployment strategy."}
+        }
+      },
+      "io.k8s.api.apps.v1.DeploymentStrategy": {
+        "type": "object",
+        "properties": {
+          "type": {"type": "string"},
+          "rollbackTo": {"type": "object", "description": "Deprecated: rollback is not supported anymore."}
+        }
+      }
+    }
+  }
+}`
+
+func TestOpenApi3NestedDeprecation(t *testing.T) {
+	kc := NewKubeCheckerImpl()
+	ks, err := kc.parseOpenApi3Docs([][]byte{[]byte(testDeprecatedFieldOpenApi3Spec)})
+	assert.NoError(t, err)
+	assert.NoError(t, kc.setSpec("1.29", ks))
+	tests := []struct {
+		name       string
+		deployment string
+		wantFields []string
+	}{
+		{
+			name:       "deprecated field below references",
+			deployment: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "app"}, "spec": {"strategy": {"rollbackTo": {}}}}`,
+			wantFields: []string{"spec/strategy/rollbackTo"},
+		},
+		{
+			name:       "deprecated field unset",
+			deployment: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "app"}, "spec": {"strategy": {"type": "Recreate"}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := kc.ValidateJson(tt.deployment, "1.29")
+			assert.NoError(t, err)
+			var fields []string
+			for _, deprecation := range result.DeprecationForOriginal {
+				fields = append(fields, strings.Join(deprecation.JSONPointer(), "/"))
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
@@ -57,6 +57,9 @@ var (
 	green   = color.New(color.FgHiGreen, color.Underline).SprintFunc()
 )
 
+// schemaNotFoundHint heads resources whose kind has no schema
+const schemaNotFoundHint = ">>> No schema for these kinds, pass definitions of custom resources along with manifests or use --ignore-missing-schemas to skip them <<<"
+
 func validOutputs() []string {
 	return []string{
 		outputSTD,
@@ -96,10 +99,26 @@ func (s *STDOutputManager) PutBulk(results []ValidationResult) error {
 	var deprecated []ValidationResult
 	var newerVersion []ValidationResult
 	var unchanged []ValidationResult
+	var unknownFields []ValidationResult
+	var schemaNotFound []ValidationResult
+	var ruleViolations []ValidationResult
+	var behaviourChanges []ValidationResult
 
 	for _, result := range results {
 		if len(result.Kind) == 0 {
 			continue
+		}
+		if len(result.UnknownFields) > 0 {
+			unknownFields = append(unknownFields, result)
+		}
+		if len(result.RuleViolations) > 0 {
+			ruleViolations = append(ruleViolations, result)
+		}
+		if len(result.BehaviourChanges) > 0 {
+			behaviourChanges = append(behaviourChanges, result)
+		}
+		if result.SchemaNotFound {
+			schemaNotFound = append(schemaNotFound, result)
 		} else if result.Deleted {
 			deleted = append(deleted, result)
 			/*} else if result.Deprecated && len(result.LatestAPIVersion) > 0 {
@@ -110,7 +129,8 @@ func (s *STDOutputManager) PutBulk(results []ValidationResult) error {
 			newerVersion = append(newerVersion, result)
 		} else {
 			if len(result.ErrorsForOriginal) == 0 && len(result.ErrorsForLatest) == 0 &&
-				len(result.DeprecationForOriginal) == 0 && len(result.DeprecationForLatest) == 0 {
+				len(result.DeprecationForOriginal) == 0 && len(result.DeprecationForLatest) == 0 &&
+				len(result.UnknownFields) == 0 {
 				unchanged = append(unchanged, result)
 			}
 		}
@@ -138,10 +158,16 @@ func (s *STDOutputManager) PutBulk(results []ValidationResult) error {
 		fmt.Printf("%s\n", yellow(">>>> Deprecated API Version's <<<<"))
 		s.SummaryTableBodyOutput(deprecated)
 		fmt.Println("")
+		for _, result := range deprecated {
+			if len(result.DeprecationWarning) > 0 {
+				fmt.Printf("%s/%s: %s\n", result.Kind, result.QualifiedName(), result.DeprecationWarning)
+			}
+		}
 		s.DeprecationTableBodyOutput(deprecated, true)
 		s.ValidationErrorTableBodyOutput(deprecated, true)
 		s.DeprecationTableBodyOutput(deprecated, false)
 		s.ValidationErrorTableBodyOutput(deprecated, false)
+		s.FieldRemovalTableBodyOutput(deprecated)
 	}
 	if len(newerVersion) > 0 {
 		sort.Slice(newerVersion, func(i, j int) bool {
@@ -155,6 +181,7 @@ func (s *STDOutputManager) PutBulk(results []ValidationResult) error {
 		s.ValidationErrorTableBodyOutput(newerVersion, true)
 		s.DeprecationTableBodyOutput(newerVersion, false)
 		s.ValidationErrorTableBodyOutput(newerVersion, false)
+		s.FieldRemovalTableBodyOutput(newerVersion)
 	}
 	if len(unchanged) > 0 {
 
@@ -164,8 +191,32 @@ func (s *STDOutputManager) PutBulk(results []ValidationResult) error {
 		s.DeprecationTableBodyOutput(unchanged, true)
 		s.ValidationErrorTableBodyOutput(unchanged, true)
 	}
+	if len(schemaNotFound) > 0 {
+		red := color.New(color.FgHiRed, color.Underline).SprintFunc()
+		fmt.Printf("%s\n", red(">>>> Schema Not Found <<<<"))
+		fmt.Println("")
+		s.SchemaNotFoundTableBodyOutput(schemaNotFound)
+	}
+	if len(unknownFields) > 0 {
+		red := color.New(color.FgHiRed, color.Underline).SprintFunc()
+		fmt.Printf("%s\n", red(">>>> Unknown Fields <<<<"))
+		fmt.Println("")
+		s.UnknownFieldTableBodyOutput(unknownFields)
+	}
+	if len(behaviourChanges) > 0 {
+		yellow := color.New(color.FgHiYellow, color.Underline).SprintFunc()
+		fmt.Printf("%s\n", yellow(">>>> Behaviour Changes <<<<"))
+		fmt.Println("")
+		s.BehaviourChangeTableBodyOutput(behaviourChanges)
+	}
+	if len(ruleViolations) > 0 {
+		red := color.New(color.FgHiRed, color.Underline).SprintFunc()
+		fmt.Printf("%s\n", red(">>>> Rule Violations <<<<"))
+		fmt.Println("")
+		s.RuleViolationTableBodyOutput(ruleViolations)
+	}
 
-	if len(deleted)+len(deprecated)+len(newerVersion)+len(unchanged) == 0 {
+	if len(deleted)+len(deprecated)+len(newerVersion)+len(unchanged)+len(unknownFields)+len(schemaNotFound)+len(ruleViolations)+len(behaviourChanges) == 0 {
 		fmt.Printf("%s\n", green("Great!!! Everything will work as it is in new version without any changes"))
 	}
 	return nil
@@ -219,7 +270,7 @@ func (s *STDOutputManager) DeprecationTableBodyOutput(results []ValidationResult
 	if !currentVersion {
 		apiVersionHeader = "API Version (Latest Available)"
 	}
-	t := table.Table{Headers: []string{"Namespace", "Name", "Kind", apiVersionHeader, "Field", "Reason"}}
+	t := table.Table{Headers: []string{"Namespace", "Name", "Kind", apiVersionHeader, "Field", "Reason", "Rule", "Remediation"}}
 	c := table.DefaultConfig()
 	c.TitleColorCode = ansi.ColorCode("cyan+bu")
 	c.AltColorCodes = []string{ansi.LightWhite, ansi.ColorCode("white+h:237")}
@@ -232,7 +283,7 @@ func (s *STDOutputManager) DeprecationTableBodyOutput(results []ValidationResult
 			errors = result.DeprecationForOriginal
 		}
 		for _, e := range errors {
-			t.Rows = append(t.Rows, []string{result.ResourceNamespace, result.ResourceName, result.Kind, apiVersion, strings.Join(e.JSONPointer(), "/"), e.Reason})
+			t.Rows = append(t.Rows, []string{result.ResourceNamespace, result.ResourceName, result.Kind, apiVersion, strings.Join(e.JSONPointer(), "/"), e.Reason, e.RuleID, e.Remediation})
 		}
 	}
 	c.Color = !s.noColor
@@ -291,6 +342,102 @@ func (s *STDOutputManager) ValidationErrorTableBodyOutput(results []ValidationRe
 	fmt.Println("")
 }
 
+func (s *STDOutputManager) FieldRemovalTableBodyOutput(results []ValidationResult) {
+	t := table.Table{Headers: []string{"Namespace", "Name", "Kind", "API Version (Current Available)", "API Version (Latest Available)", "Field"}}
+	for _, result := range results {
+		for _, e := range result.FieldRemovals {
+			t.Rows = append(t.Rows, []string{result.ResourceNamespace, result.ResourceName, result.Kind, result.APIVersion, result.LatestAPIVersion, strings.Join(e.JSONPointer(), "/")})
+		}
+	}
+	if len(t.Rows) == 0 {
+		return
+	}
+	fmt.Println(hiWhite(">>> Fields removed in latest api version, they are dropped silently after migration <<<"))
+	c := table.DefaultConfig()
+	c.TitleColorCode = ansi.ColorCode("cyan+bu")
+	c.AltColorCodes = []string{ansi.LightWhite, ansi.ColorCode("white+h:237")}
+	c.ShowIndex = false
+	c.Color = !s.noColor
+	t.WriteTable(os.Stdout, c)
+	fmt.Println("")
+}
+
+func (s *STDOutputManager) SchemaNotFoundTableBodyOutput(results []ValidationResult) {
+	t := table.Table{Headers: []string{"Namespace", "Name", "Kind", "API Version"}}
+	for _, result := range results {
+		t.Rows = append(t.Rows, []string{result.ResourceNamespace, result.ResourceName, result.Kind, result.APIVersion})
+	}
+	fmt.Println(hiWhite(schemaNotFoundHint))
+	c := table.DefaultConfig()
+	c.TitleColorCode = ansi.ColorCode("cyan+bu")
+	c.AltColorCodes = []string{ansi.LightWhite, ansi.ColorCode("white+h:237")}
+	c.ShowIndex = false
+	c.Color = !s.noColor
+	t.WriteTable(os.Stdout, c)
+	fmt.Println("")
+}
+
+func (s *STDOutputManager) UnknownFieldTableBodyOutput(results []ValidationResult) {
+	t := table.Table{Headers: []string{"Namespace", "Name", "Kind", "API Version", "Field"}}
+	for _, result := range results {
+		for _, e := range result.UnknownFields {
+			t.Rows = append(t.Rows, []string{result.ResourceNamespace, result.ResourceName, result.Kind, result.APIVersion, strings.Join(e.JSONPointer(), "/")})
+		}
+	}
+	if len(t.Rows) == 0 {
+		return
+	}
+	fmt.Println(hiWhite(">>> Fields unknown to the schema, kubectl apply --validate=strict rejects them <<<"))
+	c := table.DefaultConfig()
+	c.TitleColorCode = ansi.ColorCode("cyan+bu")
+	c.AltColorCodes = []string{ansi.LightWhite, ansi.ColorCode("white+h:237")}
+	c.ShowIndex = false
+	c.Color = !s.noColor
+	t.WriteTable(os.Stdout, c)
+	fmt.Println("")
+}
+
+func (s *STDOutputManager) BehaviourChangeTableBodyOutput(results []ValidationResult) {
+	t := table.Table{Headers: []string{"Namespace", "Name", "Kind", "API Version", "Migrate To", "Field", "Severity", "Change", "Remediation"}}
+	for _, result := range results {
+		for _, e := range result.BehaviourChanges {
+			t.Rows = append(t.Rows, []string{result.ResourceNamespace, result.ResourceName, result.Kind, result.APIVersion, result.LatestAPIVersion,
+				strings.Join(e.JSONPointer(), "/"), e.Severity, e.Reason, e.Remediation})
+		}
+	}
+	if len(t.Rows) == 0 {
+		return
+	}
+	fmt.Println(hiWhite(">>> Migrations which are valid against the schema but change behaviour <<<"))
+	c := table.DefaultConfig()
+	c.TitleColorCode = ansi.ColorCode("cyan+bu")
+	c.AltColorCodes = []string{ansi.LightWhite, ansi.ColorCode("white+h:237")}
+	c.ShowIndex = false
+	c.Color = !s.noColor
+	t.WriteTable(os.Stdout, c)
+	fmt.Println("")
+}
+
+func (s *STDOutputManager) RuleViolationTableBodyOutput(results []ValidationResult) {
+	t := table.Table{Headers: []string{"Namespace", "Name", "Kind", "API Version", "Rule", "Severity", "Message", "Remediation"}}
+	for _, result := range results {
+		for _, e := range result.RuleViolations {
+			t.Rows = append(t.Rows, []string{result.ResourceNamespace, result.ResourceName, result.Kind, result.APIVersion, e.RuleID, e.Severity, e.Reason, e.Remediation})
+		}
+	}
+	if len(t.Rows) == 0 {
+		return
+	}
+	fmt.Println(hiWhite(">>> Checks of custom rules which resources do not pass <<<"))
+	c := table.DefaultConfig()
+	c.TitleColorCode = ansi.ColorCode("cyan+bu")
+	c.AltColorCodes = []string{ansi.LightWhite, ansi.ColorCode("white+h:237")}
+	c.ShowIndex = false
+	c.Color = !s.noColor
+	t.WriteTable(os.Stdout, c)
+	fmt.Println("")
+}
+
 func (s *STDOutputManager) Put(result ValidationResult) error {
 	openapi3.SchemaErrorDetailsDisabled = true
 	return nil
@@ -305,6 +452,206 @@ func (j *STDOutputManager) GetSummaryValidationResultBulk() []SummaryValidationR
 	return nil
 }
 
+// PutUpgradePathResults reports results of validation against every release of an upgrade path in outFmt, stdout
+// unless it is json
+func PutUpgradePathResults(results []UpgradePathResult, releases []string, outFmt string, noColor bool) error {
+	if outFmt == outputJSON {
+		b, err := json.MarshalIndent(results, "", "\t")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	s := newSTDOutputManager(noColor)
+	var affected []UpgradePathResult
+	var schemaNotFound []UpgradePathResult
+	for _, result := range results {
+		if len(result.Kind) > 0 && (len(result.DeprecatedInRelease) > 0 || len(result.RemovedInRelease) > 0 ||
+			len(result.FieldDeprecations) > 0 || result.HasBlockers()) {
+			affected = append(affected, result)
+		}
+		if len(result.Kind) > 0 && result.HasMissingSchema() {
+			schemaNotFound = append(schemaNotFound, result)
+		}
+	}
+	if len(affected)+len(schemaNotFound) == 0 {
+		fmt.Printf("%s\n", green(fmt.Sprintf("Great!!! Nothing blocks the upgrade from %s to %s", releases[0], releases[len(releases)-1])))
+		return nil
+	}
+	if len(affected) > 0 {
+		s.UpgradePathSummaryTableBodyOutput(affected)
+		for _, release := range releases[1:] {
+			s.UpgradeStepTableBodyOutput(affected, release)
+		}
+		s.FieldDeprecationTableBodyOutput(affected)
+	}
+	s.UpgradePathSchemaNotFoundTableBodyOutput(schemaNotFound)
+	return nil
+}
+
+func (s *STDOutputManager) UpgradePathSchemaNotFoundTableBodyOutput(results []UpgradePathResult) {
+	t := table.Table{Headers: []string{"Namespace", "Name", "Kind", "API Version", "Schema Not Found In"}}
+	for _, result := range results {
+		var missing []string
+		for _, step := range result.Steps {
+			if step.Status == UpgradeStatusSchemaNotFound {
+				missing = append(missing, step.ReleaseVersion)
+			}
+		}
+		t.Rows = append(t.Rows, []string{result.ResourceNamespace, result.ResourceName, result.Kind, result.APIVersion, strings.Join(missing, ", ")})
+	}
+	if len(t.Rows) == 0 {
+		return
+	}
+	fmt.Println(hiWhite(schemaNotFoundHint))
+	c := table.DefaultConfig()
+	c.TitleColorCode = ansi.ColorCode("cyan+bu")
+	c.AltColorCodes = []string{ansi.LightWhite, ansi.ColorCode("white+h:237")}
+	c.ShowIndex = false
+	c.Color = !s.noColor
+	t.WriteTable(os.Stdout, c)
+	fmt.Println("")
+}
+
+func (s *STDOutputManager) UpgradePathSummaryTableBodyOutput(results []UpgradePathResult) {
+	t := table.Table{Headers: []string{"Namespace", "Name", "Kind", "API Version", "Deprecated In", "Removed In", "Replace With API Version"}}
+	c := table.DefaultConfig()
+	c.TitleColorCode = ansi.ColorCode("cyan+bu")
+	c.AltColorCodes = []string{ansi.LightWhite, ansi.ColorCode("white+h:238")}
+	c.ShowIndex = false
+	for _, result := range results {
+		t.Rows = append(t.Rows, []string{result.ResourceNamespace, result.ResourceName, result.Kind, result.APIVersion, result.DeprecatedInRelease, result.RemovedInRelease, result.ReplacementAPIVersion})
+	}
+	c.Color = !s.noColor
+	t.WriteTable(os.Stdout, c)
+	fmt.Println("")
+}
+
+func (s *STDOutputManager) UpgradeStepTableBodyOutput(results []UpgradePathResult, release string) {
+	t := table.Table{Headers: []string{"Namespace", "Name", "Kind", "Fix"}}
+	for _, result := range results {
+		for _, step := range result.Steps {
+			if step.ReleaseVersion != release {
+				continue
+			}
+			for _, blocker := range step.Blockers {
+				t.Rows = append(t.Rows, []string{result.ResourceNamespace, result.ResourceName, result.Kind, blocker})
+			}
+		}
+	}
+	if len(t.Rows) == 0 {
+		return
+	}
+	red := color.New(color.FgHiRed, color.Underline).SprintFunc()
+	fmt.Printf("%s\n", red(fmt.Sprintf(">>>> Must be fixed before upgrading to %s <<<<", release)))
+	c := table.DefaultConfig()
+	c.TitleColorCode = ansi.ColorCode("cyan+bu")
+	c.AltColorCodes = []string{ansi.LightWhite, ansi.ColorCode("white+h:237")}
+	c.ShowIndex = false
+	c.Color = !s.noColor
+	t.WriteTable(os.Stdout, c)
+	fmt.Println("")
+}
+
+func (s *STDOutputManager) FieldDeprecationTableBodyOutput(results []UpgradePathResult) {
+	t := table.Table{Headers: []string{"Namespace", "Name", "Kind", "Field", "Deprecated In", "Reason"}}
+	for _, result := range results {
+		for _, deprecation := range result.FieldDeprecations {
+			t.Rows = append(t.Rows, []string{result.ResourceNamespace, result.ResourceName, result.Kind, deprecation.Field, deprecation.ReleaseVersion, deprecation.Reason})
+		}
+	}
+	if len(t.Rows) == 0 {
+		return
+	}
+	fmt.Println(hiWhite("Deprecated fields along the upgrade path, recommended to resolve them"))
+	c := table.DefaultConfig()
+	c.TitleColorCode = ansi.ColorCode("cyan+bu")
+	c.AltColorCodes = []string{ansi.LightWhite, ansi.ColorCode("white+h:237")}
+	c.ShowIndex = false
+	c.Color = !s.noColor
+	t.WriteTable(os.Stdout, c)
+	fmt.Println("")
+}
+
+// PutSchemaDiff reports differences between schemas of two kubernetes releases in outFmt, stdout unless it is json
+func PutSchemaDiff(diff *SchemaDiff, outFmt string, noColor bool) error {
+	if outFmt == outputJSON {
+		b, err := json.MarshalIndent(diff, "", "\t")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	s := newSTDOutputManager(noColor)
+	if len(diff.RemovedKinds)+len(diff.AddedKinds)+len(diff.ChangedKinds) == 0 {
+		fmt.Printf("%s\n", green(fmt.Sprintf("Great!!! Schemas of %s and %s are the same", diff.FromRelease, diff.ToRelease)))
+		return nil
+	}
+	if len(diff.RemovedKinds) > 0 {
+		red := color.New(color.FgHiRed, color.Underline).SprintFunc()
+		fmt.Printf("%s\n", red(fmt.Sprintf(">>>> Removed in %s <<<<", diff.ToRelease)))
+		s.KindTableBodyOutput(diff.RemovedKinds)
+	}
+	if len(diff.AddedKinds) > 0 {
+		fmt.Printf("%s\n", green(fmt.Sprintf(">>>> Added in %s <<<<", diff.ToRelease)))
+		s.KindTableBodyOutput(diff.AddedKinds)
+	}
+	if len(diff.ChangedKinds) > 0 {
+		yellow := color.New(color.FgHiYellow, color.Underline).SprintFunc()
+		fmt.Printf("%s\n", yellow(fmt.Sprintf(">>>> Changed from %s to %s <<<<", diff.FromRelease, diff.ToRelease)))
+		s.FieldChangeTableBodyOutput(diff.ChangedKinds)
+	}
+	return nil
+}
+
+func (s *STDOutputManager) KindTableBodyOutput(kindDiffs []KindDiff) {
+	t := table.Table{Headers: []string{"Kind", "API Version"}}
+	c := table.DefaultConfig()
+	c.TitleColorCode = ansi.ColorCode("cyan+bu")
+	c.AltColorCodes = []string{ansi.LightWhite, ansi.ColorCode("white+h:238")}
+	c.ShowIndex = false
+	for _, kindDiff := range kindDiffs {
+		t.Rows = append(t.Rows, []string{kindDiff.Kind, kindDiff.APIVersion()})
+	}
+	c.Color = !s.noColor
+	t.WriteTable(os.Stdout, c)
+	fmt.Println("")
+}
+
+func (s *STDOutputManager) FieldChangeTableBodyOutput(kindDiffs []KindDiff) {
+	t := table.Table{Headers: []string{"Kind", "API Version", "Field", "Change", "From", "To"}}
+	c := table.DefaultConfig()
+	c.TitleColorCode = ansi.ColorCode("cyan+bu")
+	c.AltColorCodes = []string{ansi.LightWhite, ansi.ColorCode("white+h:237")}
+	c.ShowIndex = false
+	for _, kindDiff := range kindDiffs {
+		for _, change := range kindDiff.Changes {
+			field := change.Field
+			if len(field) == 0 {
+				field = "-"
+			}
+			t.Rows = append(t.Rows, []string{kindDiff.Kind, kindDiff.APIVersion(), field, change.Change, shortText(change.From), shortText(change.To)})
+		}
+	}
+	c.Color = !s.noColor
+	t.WriteTable(os.Stdout, c)
+	fmt.Println("")
+}
+
+// shortText returns first line of text cut to fit in a table cell
+func shortText(text string) string {
+	const maxLength = 80
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = text[:i]
+	}
+	if runes := []rune(text); len(runes) > maxLength {
+		text = string(runes[:maxLength-3]) + "..."
+	}
+	return text
+}
+
 type status string
 
 const (
@@ -342,6 +689,10 @@ func getStatus(r ValidationResult) status {
 		return statusSkipped
 	}
 
+	if r.SchemaNotFound || r.HasRuleErrors() || r.HasBehaviourChangeErrors() {
+		return statusInvalid
+	}
+
 	if !r.ValidatedAgainstSchema {
 		return statusSkipped
 	}
@@ -356,19 +707,23 @@ func getStatus(r ValidationResult) status {
 func (j *jsonOutputManager) PutBulk(vrs []ValidationResult) error {
 	svrs := make([]SummaryValidationResult, 0, len(vrs))
 	for _, vr := range vrs {
-		if vr.Deleted == false && vr.Deprecated == false && len(vr.ErrorsForLatest) == 0 && len(vr.ErrorsForOriginal) == 0 && len(vr.DeprecationForLatest) == 0 && len(vr.DeprecationForOriginal) == 0 {
+		if vr.Deleted == false && vr.Deprecated == false && vr.SchemaNotFound == false && len(vr.ErrorsForLatest) == 0 && len(vr.ErrorsForOriginal) == 0 && len(vr.DeprecationForLatest) == 0 && len(vr.DeprecationForOriginal) == 0 && len(vr.FieldRemovals) == 0 && len(vr.UnknownFields) == 0 && len(vr.RuleViolations) == 0 && len(vr.BehaviourChanges) == 0 {
 			continue
 		}
 		svr := SummaryValidationResult{
-			Deleted:            vr.Deleted,
-			Deprecated:         vr.Deprecated,
-			Kind:               vr.Kind,
-			ResourceName:       vr.ResourceName,
-			APIVersion:         vr.APIVersion,
-			FileName:           vr.FileName,
-			IsVersionSupported: vr.IsVersionSupported,
-			LatestAPIVersion:   vr.LatestAPIVersion,
-			ResourceNamespace:  vr.ResourceNamespace,
+			Deleted:             vr.Deleted,
+			Deprecated:          vr.Deprecated,
+			DeprecationWarning:  vr.DeprecationWarning,
+			Kind:                vr.Kind,
+			ResourceName:        vr.ResourceName,
+			APIVersion:          vr.APIVersion,
+			FileName:            vr.FileName,
+			IsVersionSupported:  vr.IsVersionSupported,
+			SchemaNotFound:      vr.SchemaNotFound,
+			LatestAPIVersion:    vr.LatestAPIVersion,
+			DeprecatedInRelease: vr.DeprecatedInRelease,
+			RemovedInRelease:    vr.RemovedInRelease,
+			ResourceNamespace:   vr.ResourceNamespace,
 		}
 		for _, se := range vr.ErrorsForOriginal {
 			sse := &SummarySchemaError{
@@ -394,6 +749,9 @@ func (j *jsonOutputManager) PutBulk(vrs []ValidationResult) error {
 				SchemaField: se.SchemaField,
 				Reason:      se.Reason,
 				Origin:      se.Origin,
+				RuleID:      se.RuleID,
+				Severity:    se.Severity,
+				Remediation: se.Remediation,
 			}
 			svr.DeprecationForOriginal = append(svr.DeprecationForOriginal, sse)
 		}
@@ -403,12 +761,55 @@ func (j *jsonOutputManager) PutBulk(vrs []ValidationResult) error {
 				SchemaField: se.SchemaField,
 				Reason:      se.Reason,
 				Origin:      se.Origin,
+				RuleID:      se.RuleID,
+				Severity:    se.Severity,
+				Remediation: se.Remediation,
 			}
 			svr.DeprecationForLatest = append(svr.DeprecationForLatest, sse)
 		}
+		for _, se := range vr.FieldRemovals {
+			sse := &SummarySchemaError{
+				Path:        strings.Join(se.JSONPointer(), "/"),
+				SchemaField: se.SchemaField,
+				Reason:      se.Reason,
+				Origin:      se.Origin,
+			}
+			svr.FieldRemovals = append(svr.FieldRemovals, sse)
+		}
+		for _, se := range vr.UnknownFields {
+			sse := &SummarySchemaError{
+				Path:        strings.Join(se.JSONPointer(), "/"),
+				SchemaField: se.SchemaField,
+				Reason:      se.Reason,
+				Origin:      se.Origin,
+			}
+			svr.UnknownFields = append(svr.UnknownFields, sse)
+		}
+		for _, se := range vr.RuleViolations {
+			sse := &SummarySchemaError{
+				Path:        strings.Join(se.JSONPointer(), "/"),
+				Reason:      se.Reason,
+				RuleID:      se.RuleID,
+				Severity:    se.Severity,
+				Remediation: se.Remediation,
+			}
+			svr.RuleViolations = append(svr.RuleViolations, sse)
+		}
+		for _, se := range vr.BehaviourChanges {
+			sse := &SummarySchemaError{
+				Path:        strings.Join(se.JSONPointer(), "/"),
+				SchemaField: se.SchemaField,
+				Reason:      se.Reason,
+				RuleID:      se.RuleID,
+				Severity:    se.Severity,
+				Remediation: se.Remediation,
+			}
+			svr.BehaviourChanges = append(svr.BehaviourChanges, sse)
+		}
+		locateSummaryValidationResult(&svr, vr)
 		svrs = append(svrs, svr)
 	}
-	j.data = svrs
+	j.data = append(j.data, svrs...)
 	return nil
 }
 
@@ -422,14 +823,18 @@ func (j *jsonOutputManager) Put(vr ValidationResult) error {
 	//}
 
 	svr := SummaryValidationResult{
-		Deleted:            vr.Deleted,
-		Deprecated:         vr.Deprecated,
-		Kind:               vr.Kind,
-		ResourceName:       vr.ResourceName,
-		APIVersion:         vr.APIVersion,
-		FileName:           vr.FileName,
-		IsVersionSupported: vr.IsVersionSupported,
-		LatestAPIVersion:   vr.LatestAPIVersion,
+		Deleted:             vr.Deleted,
+		Deprecated:          vr.Deprecated,
+		DeprecationWarning:  vr.DeprecationWarning,
+		Kind:                vr.Kind,
+		ResourceName:        vr.ResourceName,
+		APIVersion:          vr.APIVersion,
+		FileName:            vr.FileName,
+		IsVersionSupported:  vr.IsVersionSupported,
+		SchemaNotFound:      vr.SchemaNotFound,
+		LatestAPIVersion:    vr.LatestAPIVersion,
+		DeprecatedInRelease: vr.DeprecatedInRelease,
+		RemovedInRelease:    vr.RemovedInRelease,
 	}
 	for _, se := range vr.ErrorsForOriginal {
 		sse := &SummarySchemaError{
@@ -455,6 +860,9 @@ func (j *jsonOutputManager) Put(vr ValidationResult) error {
 			SchemaField: se.SchemaField,
 			Reason:      se.Reason,
 			Origin:      se.Origin,
+			RuleID:      se.RuleID,
+			Severity:    se.Severity,
+			Remediation: se.Remediation,
 		}
 		svr.DeprecationForOriginal = append(svr.DeprecationForOriginal, sse)
 	}
@@ -464,15 +872,77 @@ func (j *jsonOutputManager) Put(vr ValidationResult) error {
 			SchemaField: se.SchemaField,
 			Reason:      se.Reason,
 			Origin:      se.Origin,
+			RuleID:      se.RuleID,
+			Severity:    se.Severity,
+			Remediation: se.Remediation,
 		}
 		svr.DeprecationForLatest = append(svr.DeprecationForLatest, sse)
 	}
+	for _, se := range vr.FieldRemovals {
+		sse := &SummarySchemaError{
+			Path:        strings.Join(se.JSONPointer(), "/"),
+			SchemaField: se.SchemaField,
+			Reason:      se.Reason,
+			Origin:      se.Origin,
+		}
+		svr.FieldRemovals = append(svr.FieldRemovals, sse)
+	}
+	for _, se := range vr.UnknownFields {
+		sse := &SummarySchemaError{
+			Path:        strings.Join(se.JSONPointer(), "/"),
+			SchemaField: se.SchemaField,
+			Reason:      se.Reason,
+			Origin:      se.Origin,
+		}
+		svr.UnknownFields = append(svr.UnknownFields, sse)
+	}
+	for _, se := range vr.RuleViolations {
+		sse := &SummarySchemaError{
+			Path:        strings.Join(se.JSONPointer(), "/"),
+			Reason:      se.Reason,
+			RuleID:      se.RuleID,
+			Severity:    se.Severity,
+			Remediation: se.Remediation,
+		}
+		svr.RuleViolations = append(svr.RuleViolations, sse)
+	}
+	for _, se := range vr.BehaviourChanges {
+		sse := &SummarySchemaError{
+			Path:        strings.Join(se.JSONPointer(), "/"),
+			SchemaField: se.SchemaField,
+			Reason:      se.Reason,
+			RuleID:      se.RuleID,
+			Severity:    se.Severity,
+			Remediation: se.Remediation,
+		}
+		svr.BehaviourChanges = append(svr.BehaviourChanges, sse)
+	}
+	locateSummaryValidationResult(&svr, vr)
 
 	j.data = append(j.data, svr)
 
 	return nil
 }
 
+// locateSummaryValidationResult sets positions of the resource and of its findings in the file of the resource
+func locateSummaryValidationResult(svr *SummaryValidationResult, vr ValidationResult) {
+	svr.DocumentIndex = vr.DocumentIndex
+	svr.ListItemPath = vr.ListItemPath
+	svr.Line = vr.Line
+	svr.Column = vr.Column
+	if vr.Line == 0 {
+		return
+	}
+	for _, errs := range [][]*SummarySchemaError{svr.ErrorsForOriginal, svr.ErrorsForLatest, svr.DeprecationForOriginal,
+		svr.DeprecationForLatest, svr.FieldRemovals, svr.UnknownFields, svr.RuleViolations, svr.BehaviourChanges} {
+		for _, sse := range errs {
+			position := vr.PositionOf(sse.Path)
+			sse.Line = position.Line
+			sse.Column = position.Column
+		}
+	}
+}
+
 func (j *jsonOutputManager) Flush() error {
 	b, err := json.Marshal(j.data)
 	if err != nil {
@@ -523,6 +993,19 @@ func (j *tapOutputManager) Put(r ValidationResult) error {
 	for _, e := range r.Errors {
 		errs = append(errs, e.String())
 	}
+	if r.SchemaNotFound {
+		errs = append(errs, fmt.Sprintf("schema not found for %s", r.VersionKind()))
+	}
+	for _, violation := range r.RuleViolations {
+		if violation.Severity == SeverityError {
+			errs = append(errs, fmt.Sprintf("%s: %s", violation.RuleID, violation.Reason))
+		}
+	}
+	for _, change := range r.BehaviourChanges {
+		if change.Severity == SeverityError {
+			errs = append(errs, fmt.Sprintf("%s: %s", strings.Join(change.JSONPointer(), "/"), change.Reason))
+		}
+	}
 
 	j.data = append(j.data, dataEvalResult{
 		Filename: r.FileName,
@@ -201,3 +201,15 @@ ok 1 - deployment.yaml (Deployment) # SKIP
 		})
 	}
 }
+
+func Test_jsonOutputManager_PutBulk(t *testing.T) {
+	j := newJSONOutputManager(log.New(&bytes.Buffer{}, "", 0))
+	// results of every file are put separately
+	assert.NoError(t, j.PutBulk([]ValidationResult{{FileName: "a.yaml", Kind: "Deployment", APIVersion: "apps/v1beta2", ResourceName: "a", Deprecated: true}}))
+	assert.NoError(t, j.PutBulk([]ValidationResult{{FileName: "b.yaml", Kind: "Ingress", APIVersion: "extensions/v1beta1", ResourceName: "b", Deleted: true}}))
+	var fileNames []string
+	for _, result := range j.GetSummaryValidationResultBulk() {
+		fileNames = append(fileNames, result.FileName)
+	}
+	assert.Equal(t, []string{"a.yaml", "b.yaml"}, fileNames)
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"fmt"
+	"github.com/devtron-labs/silver-surfer/pkg/errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// LatestReleaseVersion is the latest kubernetes release known to this version of kubedd, latest resolves to it
+	// unless a newer release is found in schema bundles or specs bundled in the binary
+	LatestReleaseVersion = "1.29"
+
+	// MasterReleaseVersion refers to the master branch of kubernetes rather than a release branch
+	MasterReleaseVersion = "master"
+)
+
+var (
+	// releaseVersionPattern matches kubernetes versions such as 1.29, v1.29.3, 1.27+ and v1.27.8-eks-4f4795d
+	releaseVersionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)\+?(\.\d+)?([-+].*)?$`)
+	// latestAliasPattern matches latest and stable followed by an optional number of releases to go back eg latest-1
+	latestAliasPattern = regexp.MustCompile(`^(latest|stable)(-(\d+))?$`)
+)
+
+// ResolveReleaseVersion maps version to the kubernetes release it refers to, i.e; major.minor or master. Patch
+// versions, build metadata and vendor suffixes are dropped, latest and stable resolve to the highest of versions and
+// LatestReleaseVersion, latest-N to the Nth minor release before it. errors.ReleaseVersionError is returned for
+// anything else.
+func ResolveReleaseVersion(version string, versions []string) (string, error) {
+	normalised := strings.ToLower(strings.TrimSpace(version))
+	if normalised == MasterReleaseVersion {
+		return MasterReleaseVersion, nil
+	}
+	if match := releaseVersionPattern.FindStringSubmatch(normalised); match != nil {
+		major, _ := strconv.Atoi(match[1])
+		minor, _ := strconv.Atoi(match[2])
+		return fmt.Sprintf("%d.%d", major, minor), nil
+	}
+	match := latestAliasPattern.FindStringSubmatch(normalised)
+	if match == nil {
+		return "", &errors.ReleaseVersionError{Version: version, Err: errors.ErrInvalidReleaseVersion}
+	}
+	latest := LatestReleaseVersion
+	for _, v := range versions {
+		if _, _, err := parseReleaseVersion(v); err == nil && compareReleaseVersion(latest, v) {
+			latest = v
+		}
+	}
+	major, minor, _ := parseReleaseVersion(latest)
+	if len(match[3]) > 0 {
+		back, _ := strconv.Atoi(match[3])
+		minor -= back
+	}
+	if minor < 0 {
+		return "", &errors.ReleaseVersionError{Version: version, Err: errors.ErrUnknownReleaseVersion}
+	}
+	return fmt.Sprintf("%d.%d", major, minor), nil
+}
+
+// releaseLocation returns location of spec of releaseVersion given a location template in which %s is replaced by
+// the version, release-master of the upstream layout is replaced by master since there is no such branch
+func releaseLocation(template, releaseVersion string) string {
+	location := fmt.Sprintf(template, releaseVersion)
+	if releaseVersion == MasterReleaseVersion {
+		location = strings.Replace(location, "/release-"+MasterReleaseVersion+"/", "/"+MasterReleaseVersion+"/", 1)
+	}
+	return location
+}
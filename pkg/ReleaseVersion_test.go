@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	goerrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/devtron-labs/silver-surfer/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveReleaseVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		versions []string
+		want     string
+		wantErr  error
+	}{
+		{name: "minor release", version: "1.29", want: "1.29"},
+		{name: "patch release with v prefix", version: "v1.29.3", want: "1.29"},
+		{name: "server version of managed cluster", version: "1.27+", want: "1.27"},
+		{name: "git version of eks", version: "v1.27.8-eks-4f4795d", want: "1.27"},
+		{name: "git version with build metadata", version: "v1.28.2+k3s1", want: "1.28"},
+		{name: "master", version: " Master ", want: MasterReleaseVersion},
+		{name: "latest", version: "latest", want: LatestReleaseVersion},
+		{name: "stable", version: "stable", want: LatestReleaseVersion},
+		{name: "latest of newer available versions", version: "latest", versions: []string{"1.22", "1.31", "master"}, want: "1.31"},
+		{name: "latest-1", version: "latest-1", versions: []string{"1.31"}, want: "1.30"},
+		{name: "latest beyond first release", version: "latest-99", wantErr: errors.ErrUnknownReleaseVersion},
+		{name: "garbage", version: "one.twenty", wantErr: errors.ErrInvalidReleaseVersion},
+		{name: "empty", version: "", wantErr: errors.ErrInvalidReleaseVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveReleaseVersion(tt.version, tt.versions)
+			if tt.wantErr != nil {
+				assert.True(t, goerrors.Is(err, tt.wantErr), "unexpected error %v", err)
+				var versionErr *errors.ReleaseVersionError
+				assert.True(t, goerrors.As(err, &versionErr))
+				assert.Equal(t, tt.version, versionErr.Version)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestKubeCheckerResolvesReleaseVersion(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(testSwaggerSpec))
+	}))
+	defer server.Close()
+
+	kc, err := NewKubeCheckerImplForConfig(&Config{SchemaLocation: server.URL})
+	assert.NoError(t, err)
+	assert.NoError(t, kc.LoadFromUrl("v1.22.3", false))
+	// every form of the same release shares the loaded spec
+	assert.True(t, kc.IsApiVersionSupported("1.22", "apps/v1", "Deployment"))
+	assert.True(t, kc.IsApiVersionSupported("1.22+", "apps/v1", "Deployment"))
+	// versions are resolved once and looked up afterwards
+	assert.Equal(t, map[string]string{"v1.22.3": "1.22", "1.22": "1.22", "1.22+": "1.22"}, kc.releaseKeys)
+	assert.NoError(t, kc.LoadFromUrl("master", false))
+	assert.Equal(t, []string{"/release-1.22/api/openapi-spec/swagger.json", "/master/api/openapi-spec/swagger.json"}, paths)
+
+	err = kc.LoadFromUrl("1.x", false)
+	assert.True(t, goerrors.Is(err, errors.ErrInvalidReleaseVersion))
+	assert.Len(t, paths, 2)
+}
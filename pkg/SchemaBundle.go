@@ -0,0 +1,357 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	bundleIndexFile = "index.json"
+
+	// SpecFormatSwagger is the swagger 2.0 swagger.json published with kubernetes releases
+	SpecFormatSwagger = "swagger"
+	// SpecFormatOpenApi3 is swagger.json already converted to openapi 3 by ConvertOpenApi2
+	SpecFormatOpenApi3 = "openapi3"
+	// SpecFormatIndex is a compact schema index created by CompileSchemaIndex
+	SpecFormatIndex = "index"
+)
+
+// SchemaBundleIndex is the index.json of a schema bundle, it maps release versions and their aliases to spec files
+type SchemaBundleIndex struct {
+	Versions map[string]*SchemaBundleEntry `json:"versions"`
+	Aliases  map[string]string             `json:"aliases,omitempty"`
+}
+
+type SchemaBundleEntry struct {
+	File   string `json:"file"`
+	Format string `json:"format"`
+	Sha256 string `json:"sha256"`
+}
+
+// SchemaBundle is a directory or tar.gz archive holding openapi specs of many kubernetes releases along with an
+// index.json describing them
+type SchemaBundle struct {
+	location string
+	index    *SchemaBundleIndex
+	dir      string
+	files    map[string][]byte
+}
+
+// IsSchemaBundle tells if location is a directory or tar.gz archive rather than a single spec file
+func IsSchemaBundle(location string) bool {
+	if isTarGz(location) {
+		return true
+	}
+	info, err := os.Stat(location)
+	return err == nil && info.IsDir()
+}
+
+// HasBundleIndex tells if location is a schema bundle i.e; tar.gz archive or directory with index.json, directories
+// of specs are not
+func HasBundleIndex(location string) bool {
+	if isTarGz(location) {
+		return true
+	}
+	_, err := os.Stat(filepath.Join(location, bundleIndexFile))
+	return err == nil
+}
+
+func isTarGz(location string) bool {
+	return strings.HasSuffix(location, ".tar.gz") || strings.HasSuffix(location, ".tgz")
+}
+
+// OpenSchemaBundle reads index.json of the bundle at location, tar.gz archives are read into memory
+func OpenSchemaBundle(location string) (*SchemaBundle, error) {
+	bundle := &SchemaBundle{location: location}
+	if isTarGz(location) {
+		files, err := readTarGz(location)
+		if err != nil {
+			return nil, err
+		}
+		bundle.files = files
+	} else {
+		bundle.dir = location
+	}
+	data, err := bundle.readFile(bundleIndexFile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema bundle %s: %w", location, err)
+	}
+	index := &SchemaBundleIndex{}
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("invalid schema bundle %s: %w", location, err)
+	}
+	bundle.index = index
+	return bundle, nil
+}
+
+func readTarGz(location string) (map[string][]byte, error) {
+	f, err := os.Open(location)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	files := map[string][]byte{}
+	err = readTar(gz, func(name string, data []byte) error {
+		files[name] = data
+		return nil
+	})
+	return files, err
+}
+
+// readTar calls visit with name and contents of every regular file of tar archive r, in the order of the archive
+func readTar(r io.Reader, visit func(name string, data []byte) error) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		if err := visit(path.Clean(strings.TrimPrefix(header.Name, "./")), data); err != nil {
+			return err
+		}
+	}
+}
+
+func (b *SchemaBundle) readFile(name string) ([]byte, error) {
+	if b.files != nil {
+		data, ok := b.files[path.Clean(name)]
+		if !ok {
+			return nil, fmt.Errorf("%s not found", name)
+		}
+		return data, nil
+	}
+	return ioutil.ReadFile(filepath.Join(b.dir, filepath.FromSlash(name)))
+}
+
+// ReleaseVersions returns sorted list of release versions present in the bundle
+func (b *SchemaBundle) ReleaseVersions() []string {
+	versions := make([]string, 0, len(b.index.Versions))
+	for version := range b.index.Versions {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareReleaseVersion(versions[i], versions[j])
+	})
+	return versions
+}
+
+// Resolve returns release version releaseVersion refers to, following aliases, if it is present in the bundle
+func (b *SchemaBundle) Resolve(releaseVersion string) (string, bool) {
+	if alias, ok := b.index.Aliases[releaseVersion]; ok {
+		releaseVersion = alias
+	}
+	_, ok := b.index.Versions[releaseVersion]
+	return releaseVersion, ok
+}
+
+// Load returns spec of releaseVersion and its format after verifying its checksum
+func (b *SchemaBundle) Load(releaseVersion string) ([]byte, string, error) {
+	resolved, ok := b.Resolve(releaseVersion)
+	if !ok {
+		return nil, "", fmt.Errorf("release version %s not found in schema bundle %s", releaseVersion, b.location)
+	}
+	entry := b.index.Versions[resolved]
+	data, err := b.readFile(entry.File)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(entry.Sha256) > 0 {
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != entry.Sha256 {
+			return nil, "", fmt.Errorf("checksum mismatch for %s in schema bundle %s", entry.File, b.location)
+		}
+	}
+	if strings.HasSuffix(entry.File, ".gz") {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, "", err
+		}
+		defer gz.Close()
+		if data, err = ioutil.ReadAll(gz); err != nil {
+			return nil, "", err
+		}
+	}
+	format := entry.Format
+	if len(format) == 0 {
+		format = SpecFormatSwagger
+	}
+	return data, format, nil
+}
+
+// PackSchemaBundle reads swagger.json of every release version from source and writes them converted to openapi 3
+// as a bundle to output, a tar.gz archive if output ends with .tar.gz or .tgz otherwise a directory. Source is
+// either an existing bundle, a directory holding <version>.json or <version>/swagger.json files, or a location
+// template in which %s is replaced by the release version. Highest release version is aliased as latest. Specs are
+// written as schema indexes, see CompileSchemaIndex, if compile is set. Url templates are downloaded with opts.
+func PackSchemaBundle(versions []string, source, output string, aliases map[string]string, compile bool, opts *SchemaSourceOptions) (*SchemaBundleIndex, error) {
+	var bundle *SchemaBundle
+	var remote *urlSchemaSource
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		var err error
+		if remote, err = newUrlSchemaSource(source, opts); err != nil {
+			return nil, err
+		}
+	} else if HasBundleIndex(source) {
+		var err error
+		if bundle, err = OpenSchemaBundle(source); err != nil {
+			return nil, err
+		}
+	}
+	index := &SchemaBundleIndex{Versions: map[string]*SchemaBundleEntry{}, Aliases: map[string]string{}}
+	files := map[string][]byte{}
+	for _, version := range versions {
+		var data []byte
+		var format string
+		var err error
+		if bundle != nil {
+			data, format, err = bundle.Load(version)
+		} else if remote != nil {
+			data, format, err = remote.Fetch(version)
+		} else {
+			data, err = readSwagger(source, version)
+			format = SpecFormatSwagger
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read openapi-spec for %s: %w", version, err)
+		}
+		name, packedFormat := version+".json.gz", SpecFormatOpenApi3
+		if compile {
+			if data, err = CompileSchemaIndex(data, format); err != nil {
+				return nil, fmt.Errorf("unable to compile openapi-spec for %s: %w", version, err)
+			}
+			name, packedFormat = version+".index.gz", SpecFormatIndex
+		} else if format == SpecFormatSwagger {
+			if data, err = ConvertOpenApi2(data); err != nil {
+				return nil, fmt.Errorf("unable to convert openapi-spec for %s: %w", version, err)
+			}
+		} else if format == SpecFormatIndex {
+			return nil, fmt.Errorf("openapi-spec for %s is a schema index which can only be packed compiled", version)
+		}
+		var buf bytes.Buffer
+		gz, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		if _, err := gz.Write(data); err != nil {
+			return nil, err
+		}
+		if err := gz.Close(); err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(buf.Bytes())
+		files[name] = buf.Bytes()
+		index.Versions[version] = &SchemaBundleEntry{File: name, Format: packedFormat, Sha256: hex.EncodeToString(sum[:])}
+	}
+	if len(versions) > 0 {
+		sorted := append([]string(nil), versions...)
+		sort.Slice(sorted, func(i, j int) bool {
+			return compareReleaseVersion(sorted[i], sorted[j])
+		})
+		index.Aliases["latest"] = sorted[len(sorted)-1]
+	}
+	for alias, version := range aliases {
+		if _, ok := index.Versions[version]; !ok {
+			return nil, fmt.Errorf("alias %s refers to %s which is not part of the bundle", alias, version)
+		}
+		index.Aliases[alias] = version
+	}
+	indexData, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	files[bundleIndexFile] = indexData
+	if isTarGz(output) {
+		return index, writeTarGz(output, files)
+	}
+	for name, data := range files {
+		if err := writeFileAtomic(filepath.Join(output, name), data); err != nil {
+			return nil, err
+		}
+	}
+	return index, nil
+}
+
+func readSwagger(source, version string) ([]byte, error) {
+	if strings.Contains(source, "%s") {
+		return ioutil.ReadFile(releaseLocation(source, version))
+	}
+	for _, name := range []string{version + ".json", filepath.Join(version, "swagger.json")} {
+		data, err := ioutil.ReadFile(filepath.Join(source, name))
+		if err == nil {
+			return data, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("neither %s.json nor %s/swagger.json found in %s", version, version, source)
+}
+
+func writeTarGz(output string, files map[string][]byte) error {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(files[name])), ModTime: time.Now(), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return writeFileAtomic(output, buf.Bytes())
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackSchemaBundle(t *testing.T) {
+	source := t.TempDir()
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(source, "1.22.json"), []byte(testSwaggerSpec), 0644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(source, "1.25"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(source, "1.25", "swagger.json"), []byte(testSwaggerSpec), 0644))
+
+	tests := []struct {
+		name    string
+		output  string
+		compile bool
+	}{
+		{name: "tar.gz bundle", output: filepath.Join(t.TempDir(), "schemas.tar.gz")},
+		{name: "directory bundle", output: filepath.Join(t.TempDir(), "schemas")},
+		{name: "compiled tar.gz bundle", output: filepath.Join(t.TempDir(), "schemas.tar.gz"), compile: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, err := PackSchemaBundle([]string{"1.25", "1.22"}, source, tt.output, map[string]string{"stable": "1.22"}, tt.compile, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]string{"latest": "1.25", "stable": "1.22"}, index.Aliases)
+
+			bundle, err := OpenSchemaBundle(tt.output)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"1.22", "1.25"}, bundle.ReleaseVersions())
+
+			kc := NewKubeCheckerImpl()
+			assert.NoError(t, kc.LoadFromPath("latest", tt.output, false))
+			assert.True(t, kc.IsApiVersionSupported("latest", "apps/v1", "Deployment"))
+			// versions other than the one passed to LoadFromPath resolve from the same bundle
+			assert.NoError(t, kc.LoadFromUrl("stable", false))
+			assert.True(t, kc.hasReleaseVersion("stable"))
+			assert.Error(t, kc.LoadFromPath("1.29", tt.output, false))
+		})
+	}
+
+	_, err := PackSchemaBundle([]string{"1.22"}, source, filepath.Join(t.TempDir(), "schemas"), map[string]string{"stable": "1.29"}, false, nil)
+	assert.Error(t, err)
+}
+
+func TestPackSchemaBundleFromUrl(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(testSwaggerSpec))
+	}))
+	defer server.Close()
+	tests := []struct {
+		name    string
+		opts    *SchemaSourceOptions
+		wantErr bool
+	}{
+		{name: "certificate is verified", opts: &SchemaSourceOptions{BearerToken: "secret"}, wantErr: true},
+		{name: "token is sent", opts: &SchemaSourceOptions{InsecureSkipTLSVerify: true}, wantErr: true},
+		{name: "options are used while downloading", opts: &SchemaSourceOptions{BearerToken: "secret", InsecureSkipTLSVerify: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "schemas")
+			_, err := PackSchemaBundle([]string{"1.22"}, server.URL+"/%s.json", output, nil, false, tt.opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			bundle, err := OpenSchemaBundle(output)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"1.22"}, bundle.ReleaseVersions())
+		})
+	}
+}
@@ -0,0 +1,255 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// CacheDirEnv overrides the default location of the schema cache
+	CacheDirEnv     = "KUBEDD_CACHE_DIR"
+	DefaultCacheTTL = 24 * time.Hour
+
+	cacheIndexDir       = "index"
+	cacheBlobDir        = "blobs"
+	cacheSwaggerSuffix  = ".swagger.json"
+	cacheOpenApi3Suffix = ".openapi3.json"
+)
+
+// SchemaCacheEntry describes openapi spec of a release version persisted in the schema cache. Raw and converted
+// specs are stored as blobs addressed by Digest, i.e. sha256 of the raw spec
+type SchemaCacheEntry struct {
+	ReleaseVersion string    `json:"releaseVersion"`
+	Url            string    `json:"url"`
+	ETag           string    `json:"etag,omitempty"`
+	LastModified   string    `json:"lastModified,omitempty"`
+	FetchedAt      time.Time `json:"fetchedAt"`
+	Digest         string    `json:"digest"`
+	Size           int64     `json:"size"`
+}
+
+// IsExpired tells if entry has to be revalidated against its origin before use
+func (e *SchemaCacheEntry) IsExpired(ttl time.Duration) bool {
+	return time.Since(e.FetchedAt) > ttl
+}
+
+// SchemaCache is an on-disk cache of downloaded openapi specs along with their converted openapi 3 form
+type SchemaCache struct {
+	dir     string
+	ttl     time.Duration
+	refresh bool
+}
+
+// NewSchemaCache returns cache rooted at dir, entries older than ttl are revalidated using ETag/Last-Modified and
+// refresh ignores cached entries altogether and downloads specs again
+func NewSchemaCache(dir string, ttl time.Duration, refresh bool) *SchemaCache {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &SchemaCache{dir: dir, ttl: ttl, refresh: refresh}
+}
+
+// DefaultSchemaCacheDir returns value of KUBEDD_CACHE_DIR if set otherwise kubedd directory inside the user cache
+// directory ($XDG_CACHE_HOME or ~/.cache on linux)
+func DefaultSchemaCacheDir() string {
+	if dir := os.Getenv(CacheDirEnv); len(dir) > 0 {
+		return dir
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "kubedd")
+}
+
+func (c *SchemaCache) Dir() string {
+	return c.dir
+}
+
+func (c *SchemaCache) TTL() time.Duration {
+	return c.ttl
+}
+
+func (c *SchemaCache) indexPath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, cacheIndexDir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *SchemaCache) blobPath(digest, suffix string) string {
+	return filepath.Join(c.dir, cacheBlobDir, digest+suffix)
+}
+
+// lookup returns cached entry for url, nil is returned in case url was never cached
+func (c *SchemaCache) lookup(url string) (*SchemaCacheEntry, error) {
+	data, err := ioutil.ReadFile(c.indexPath(url))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	entry := &SchemaCacheEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (c *SchemaCache) readRaw(entry *SchemaCacheEntry) ([]byte, error) {
+	return ioutil.ReadFile(c.blobPath(entry.Digest, cacheSwaggerSuffix))
+}
+
+func (c *SchemaCache) readConverted(entry *SchemaCacheEntry) ([]byte, error) {
+	return ioutil.ReadFile(c.blobPath(entry.Digest, cacheOpenApi3Suffix))
+}
+
+// store persists raw spec and its converted form and points entry to them
+func (c *SchemaCache) store(entry *SchemaCacheEntry, raw, converted []byte) error {
+	sum := sha256.Sum256(raw)
+	entry.Digest = hex.EncodeToString(sum[:])
+	entry.Size = int64(len(raw))
+	if err := writeFileAtomic(c.blobPath(entry.Digest, cacheSwaggerSuffix), raw); err != nil {
+		return err
+	}
+	if len(converted) > 0 {
+		if err := writeFileAtomic(c.blobPath(entry.Digest, cacheOpenApi3Suffix), converted); err != nil {
+			return err
+		}
+	}
+	return c.save(entry)
+}
+
+// touch marks entry as freshly validated against its origin
+func (c *SchemaCache) touch(entry *SchemaCacheEntry) error {
+	entry.FetchedAt = time.Now()
+	return c.save(entry)
+}
+
+func (c *SchemaCache) save(entry *SchemaCacheEntry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(c.indexPath(entry.Url), data)
+}
+
+// List returns all cached entries sorted by release version
+func (c *SchemaCache) List() ([]*SchemaCacheEntry, error) {
+	files, err := ioutil.ReadDir(filepath.Join(c.dir, cacheIndexDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []*SchemaCacheEntry
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(c.dir, cacheIndexDir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		entry := &SchemaCacheEntry{}
+		if err := json.Unmarshal(data, entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].ReleaseVersion == entries[j].ReleaseVersion {
+			return entries[i].Url < entries[j].Url
+		}
+		return entries[i].ReleaseVersion < entries[j].ReleaseVersion
+	})
+	return entries, nil
+}
+
+// Prune removes entries fetched before olderThan, zero value removes every entry. Blobs no longer referenced by
+// any entry are removed as well. Removed entries are returned.
+func (c *SchemaCache) Prune(olderThan time.Duration) ([]*SchemaCacheEntry, error) {
+	entries, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+	var removed []*SchemaCacheEntry
+	inUse := map[string]bool{}
+	for _, entry := range entries {
+		if olderThan > 0 && !entry.IsExpired(olderThan) {
+			inUse[entry.Digest] = true
+			continue
+		}
+		if err := os.Remove(c.indexPath(entry.Url)); err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+		removed = append(removed, entry)
+	}
+	blobs, err := ioutil.ReadDir(filepath.Join(c.dir, cacheBlobDir))
+	if os.IsNotExist(err) {
+		return removed, nil
+	}
+	if err != nil {
+		return removed, err
+	}
+	for _, blob := range blobs {
+		digest := strings.TrimSuffix(strings.TrimSuffix(blob.Name(), cacheSwaggerSuffix), cacheOpenApi3Suffix)
+		if inUse[digest] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(c.dir, cacheBlobDir, blob.Name())); err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
+
+// writeFileAtomic writes data to a temp file and renames it so that concurrent runs never observe partial files
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("unable to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testSwaggerSpec is a trimmed down swagger.json of a kubernetes release
+const testSwaggerSpec = `
+{
+  "swagger": "2.0",
+  "info": {"title": "Kubernetes", "version": "v1.22.0"},
+  "paths": {
+    "/apis/apps/v1/namespaces/{namespace}/deployments": {
+      "parameters": [{"name": "namespace", "in": "path", "required": true, "type": "string"}],
+      "post": {
+        "operationId": "createAppsV1NamespacedDeployment",
+        "x-kubernetes-group-version-kind": {"group": "apps", "kind": "Deployment", "version": "v1"},
+        "responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/io.k8s.api.apps.v1.Deployment"}}}
+      }
+    }
+  },
+  "definitions": {
+    "io.k8s.api.apps.v1.Deployment": {
+      "description": "Deployment enables declarative updates for Pods and ReplicaSets.",
+      "type": "object",
+      "properties": {
+        "apiVersion": {"type": "string"},
+        "kind": {"type": "string"},
+        "metadata": {"type": "object"},
+        "spec": {"$ref": "#/definitions/io.k8s.api.apps.v1.DeploymentSpec"}
+      },
+      "x-kubernetes-group-version-kind": [{"group": "apps", "kind": "Deployment", "version": "v1"}]
+    },
+    "io.k8s.api.apps.v1.DeploymentSpec": {
+      "type": "object",
+      "required": ["selector"],
+      "properties": {
+        "replicas": {"type": "integer", "format": "int32"},
+        "selector": {"type": "object"},
+        "maxSurge": {"$ref": "#/definitions/io.k8s.apimachinery.pkg.util.intstr.IntOrString"}
+      }
+    },
+    "io.k8s.apimachinery.pkg.util.intstr.IntOrString": {
+      "type": "string",
+      "format": "int-or-string"
+    }
+  }
+}`
+
+func newTestSpecServer(t *testing.T, requests *int, statuses *[]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = *requests + 1
+		if r.URL.Path != "/release-1.22/swagger.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			*statuses = append(*statuses, http.StatusNotModified)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		*statuses = append(*statuses, http.StatusOK)
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(testSwaggerSpec))
+	}))
+}
+
+func TestSchemaCache(t *testing.T) {
+	requests := 0
+	var statuses []int
+	server := newTestSpecServer(t, &requests, &statuses)
+	defer server.Close()
+	dir := t.TempDir()
+
+	newChecker := func(ttl time.Duration, refresh bool) *kubeCheckerImpl {
+		kc, err := NewKubeCheckerImplForConfig(&Config{SchemaLocation: server.URL + "/release-%s/swagger.json",
+			CacheDir: dir, CacheTTL: ttl, RefreshSchemas: refresh})
+		assert.NoError(t, err)
+		return kc
+	}
+
+	// cold cache downloads and stores the spec
+	kc := newChecker(time.Hour, false)
+	assert.NoError(t, kc.LoadFromUrl("1.22", false))
+	assert.Equal(t, []int{http.StatusOK}, statuses)
+	assert.True(t, kc.IsApiVersionSupported("1.22", "apps/v1", "Deployment"))
+
+	// fresh entry is served without hitting the origin
+	kc = newChecker(time.Hour, false)
+	assert.NoError(t, kc.LoadFromUrl("1.22", false))
+	assert.Equal(t, 1, requests)
+	assert.True(t, kc.IsApiVersionSupported("1.22", "apps/v1", "Deployment"))
+
+	// expired entry is revalidated using etag
+	kc = newChecker(time.Nanosecond, false)
+	assert.NoError(t, kc.LoadFromUrl("1.22", false))
+	assert.Equal(t, []int{http.StatusOK, http.StatusNotModified}, statuses)
+
+	// refresh downloads the spec unconditionally
+	kc = newChecker(time.Hour, true)
+	assert.NoError(t, kc.LoadFromUrl("1.22", false))
+	assert.Equal(t, []int{http.StatusOK, http.StatusNotModified, http.StatusOK}, statuses)
+
+	// unknown release version is reported as missing
+	kc = newChecker(time.Hour, false)
+	assert.Error(t, kc.LoadFromUrl("1.99", false))
+
+	schemaCache := NewSchemaCache(dir, time.Hour, false)
+	entries, err := schemaCache.List()
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "1.22", entries[0].ReleaseVersion)
+		assert.Equal(t, `"v1"`, entries[0].ETag)
+	}
+
+	removed, err := schemaCache.Prune(time.Hour)
+	assert.NoError(t, err)
+	assert.Len(t, removed, 0)
+	removed, err = schemaCache.Prune(0)
+	assert.NoError(t, err)
+	assert.Len(t, removed, 1)
+	entries, err = schemaCache.List()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 0)
+}
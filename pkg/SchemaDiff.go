@@ -0,0 +1,246 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"sort"
+	"strings"
+)
+
+const (
+	FieldChangeRemoved    = "removed"
+	FieldChangeAdded      = "added"
+	FieldChangeRequired   = "required"
+	FieldChangeType       = "type"
+	FieldChangeEnum       = "enum"
+	FieldChangeDefault    = "default"
+	FieldChangeDeprecated = "deprecated"
+
+	// mapValuesField stands for values of a map in field paths, items of arrays are not part of field paths as in
+	// kubectl explain
+	mapValuesField = "*"
+)
+
+// FieldChange is a change of a field of a kind between two releases, Field is empty for changes of the kind itself
+type FieldChange struct {
+	Field  string `json:"field"`
+	Change string `json:"change"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+// KindDiff lists changes of schema of a group/version/kind between two releases
+type KindDiff struct {
+	Group   string        `json:"group"`
+	Version string        `json:"version"`
+	Kind    string        `json:"kind"`
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// APIVersion returns group/version of the kind, just version for the core group
+func (d *KindDiff) APIVersion() string {
+	return groupVersion(d.Group, d.Version)
+}
+
+// SchemaDiff lists group/version/kinds removed and added between two releases along with changes of schemas of kinds
+// served in both
+type SchemaDiff struct {
+	FromRelease  string     `json:"fromRelease"`
+	ToRelease    string     `json:"toRelease"`
+	RemovedKinds []KindDiff `json:"removedKinds"`
+	AddedKinds   []KindDiff `json:"addedKinds"`
+	ChangedKinds []KindDiff `json:"changedKinds"`
+}
+
+// diffKubeSpecs compares group/version/kinds served in from with those served in to
+func diffKubeSpecs(fromRelease string, from *kubeSpec, toRelease string, to *kubeSpec) *SchemaDiff {
+	diff := &SchemaDiff{FromRelease: fromRelease, ToRelease: toRelease, RemovedKinds: make([]KindDiff, 0),
+		AddedKinds: make([]KindDiff, 0), ChangedKinds: make([]KindDiff, 0)}
+	fromKinds := from.servedKindInfos()
+	toKinds := to.servedKindInfos()
+	for key, fromKind := range fromKinds {
+		toKind, ok := toKinds[key]
+		if !ok {
+			diff.RemovedKinds = append(diff.RemovedKinds, fromKind.kindDiff())
+			continue
+		}
+		kindDiff := fromKind.kindDiff()
+		fromScm, fromErr := from.schemaLookup(fromKind.ComponentKey)
+		toScm, toErr := to.schemaLookup(toKind.ComponentKey)
+		if fromErr != nil || toErr != nil {
+			continue
+		}
+		kindDiff.Changes = diffSchemas("", fromScm, toScm, map[[2]*openapi3.Schema]bool{})
+		if len(kindDiff.Changes) > 0 {
+			sort.SliceStable(kindDiff.Changes, func(i, j int) bool {
+				return kindDiff.Changes[i].Field < kindDiff.Changes[j].Field
+			})
+			diff.ChangedKinds = append(diff.ChangedKinds, kindDiff)
+		}
+	}
+	for key, toKind := range toKinds {
+		if _, ok := fromKinds[key]; !ok {
+			diff.AddedKinds = append(diff.AddedKinds, toKind.kindDiff())
+		}
+	}
+	for _, kindDiffs := range [][]KindDiff{diff.RemovedKinds, diff.AddedKinds, diff.ChangedKinds} {
+		sort.Slice(kindDiffs, func(i, j int) bool {
+			lhs, rhs := kindDiffs[i], kindDiffs[j]
+			if lhs.Kind != rhs.Kind {
+				return lhs.Kind < rhs.Kind
+			}
+			if lhs.Group != rhs.Group {
+				return lhs.Group < rhs.Group
+			}
+			return compareVersion(lhs.Version, rhs.Version)
+		})
+	}
+	return diff
+}
+
+// servedKindInfo is kind info of a served group/version/kind along with the kind in its actual case
+type servedKindInfo struct {
+	*KindInfo
+	kind string
+}
+
+func (ki servedKindInfo) kindDiff() KindDiff {
+	return KindDiff{Group: ki.Group, Version: ki.Version, Kind: ki.kind}
+}
+
+// servedKindInfos returns kind info of every group/version/kind served by the spec keyed by apiVersion/kind
+func (ks *kubeSpec) servedKindInfos() map[string]servedKindInfo {
+	kindInfos := map[string]servedKindInfo{}
+	for _, api := range ks.servedApis(nil, "") {
+		for _, ki := range ks.kindInfoMap[strings.ToLower(api.kind)] {
+			if ki.Group == api.group && ki.Version == api.version && len(ki.RestPath) > 0 {
+				kindInfos[apiLifecycleKey(groupVersion(api.group, api.version), api.kind)] = servedKindInfo{KindInfo: ki, kind: api.kind}
+			}
+		}
+	}
+	return kindInfos
+}
+
+// diffSchemas returns changes of field from schema from to schema to, visiting keeps pairs of schemas being compared
+// so that recursive schemas such as those of CustomResourceDefinitions are compared once
+func diffSchemas(field string, from, to *openapi3.Schema, visiting map[[2]*openapi3.Schema]bool) []FieldChange {
+	pair := [2]*openapi3.Schema{from, to}
+	if visiting[pair] {
+		return nil
+	}
+	visiting[pair] = true
+	defer delete(visiting, pair)
+	from, to = effectiveSchema(from), effectiveSchema(to)
+
+	var changes []FieldChange
+	if len(from.Type) > 0 && len(to.Type) > 0 && from.Type != to.Type {
+		changes = append(changes, FieldChange{Field: field, Change: FieldChangeType, From: from.Type, To: to.Type})
+	}
+	if fromEnum, toEnum := enumValues(from.Enum), enumValues(to.Enum); fromEnum != toEnum {
+		changes = append(changes, FieldChange{Field: field, Change: FieldChangeEnum, From: fromEnum, To: toEnum})
+	}
+	if fromDefault, toDefault := schemaValue(from.Default), schemaValue(to.Default); fromDefault != toDefault {
+		changes = append(changes, FieldChange{Field: field, Change: FieldChangeDefault, From: fromDefault, To: toDefault})
+	}
+	if !isDeprecatedDescription(from.Description) && isDeprecatedDescription(to.Description) {
+		changes = append(changes, FieldChange{Field: field, Change: FieldChangeDeprecated, To: to.Description})
+	}
+
+	required := map[string]bool{}
+	for _, name := range from.Required {
+		required[name] = true
+	}
+	for _, name := range to.Required {
+		if !required[name] {
+			changes = append(changes, FieldChange{Field: fieldPath(field, name), Change: FieldChangeRequired})
+		}
+	}
+	for name := range from.Properties {
+		if _, ok := to.Properties[name]; !ok {
+			changes = append(changes, FieldChange{Field: fieldPath(field, name), Change: FieldChangeRemoved})
+		}
+	}
+	for name, property := range to.Properties {
+		fromProperty, ok := from.Properties[name]
+		if !ok {
+			changes = append(changes, FieldChange{Field: fieldPath(field, name), Change: FieldChangeAdded, To: schemaType(property.Value)})
+			continue
+		}
+		if fromProperty.Value != nil && property.Value != nil {
+			changes = append(changes, diffSchemas(fieldPath(field, name), fromProperty.Value, property.Value, visiting)...)
+		}
+	}
+	if from.Items != nil && to.Items != nil && from.Items.Value != nil && to.Items.Value != nil {
+		changes = append(changes, diffSchemas(field, from.Items.Value, to.Items.Value, visiting)...)
+	}
+	if from.AdditionalProperties != nil && to.AdditionalProperties != nil &&
+		from.AdditionalProperties.Value != nil && to.AdditionalProperties.Value != nil {
+		changes = append(changes, diffSchemas(fieldPath(field, mapValuesField), from.AdditionalProperties.Value, to.AdditionalProperties.Value, visiting)...)
+	}
+	return changes
+}
+
+func fieldPath(field, name string) string {
+	if len(field) == 0 {
+		return name
+	}
+	return field + "/" + name
+}
+
+// isDeprecatedDescription tells if description announces deprecation of the field or kind it describes
+func isDeprecatedDescription(description string) bool {
+	return deprecationNotice.MatchString(description) || upperCaseDeprecationNotice.MatchString(description)
+}
+
+func schemaType(scm *openapi3.Schema) string {
+	if scm == nil {
+		return ""
+	}
+	scm = effectiveSchema(scm)
+	if scm.Type == "array" && scm.Items != nil && scm.Items.Value != nil && len(scm.Items.Value.Type) > 0 {
+		return fmt.Sprintf("[]%s", scm.Items.Value.Type)
+	}
+	return scm.Type
+}
+
+// enumValues returns sorted values of enum joined by comma
+func enumValues(enum []interface{}) string {
+	values := make([]string, 0, len(enum))
+	for _, value := range enum {
+		values = append(values, schemaValue(value))
+	}
+	sort.Strings(values)
+	return strings.Join(values, ", ")
+}
+
+func schemaValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
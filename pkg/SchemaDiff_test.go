@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	object := func(properties openapi3.Schemas, required ...string) *openapi3.Schema {
+		scm := openapi3.NewObjectSchema()
+		scm.Properties = properties
+		scm.Required = required
+		return scm
+	}
+	tests := []struct {
+		name string
+		from *openapi3.Schema
+		to   *openapi3.Schema
+		want []FieldChange
+	}{
+		{
+			name: "same schema",
+			from: object(openapi3.Schemas{"replicas": openapi3.NewIntegerSchema().NewRef()}),
+			to:   object(openapi3.Schemas{"replicas": openapi3.NewIntegerSchema().NewRef()}),
+		},
+		{
+			name: "removed, added and required fields",
+			from: object(openapi3.Schemas{"selector": openapi3.NewStringSchema().NewRef()}),
+			to:   object(openapi3.Schemas{"strategy": openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()).NewRef()}, "strategy"),
+			want: []FieldChange{
+				{Field: "strategy", Change: FieldChangeRequired},
+				{Field: "selector", Change: FieldChangeRemoved},
+				{Field: "strategy", Change: FieldChangeAdded, To: "[]string"},
+			},
+		},
+		{
+			name: "type, enum, default and deprecation of nested field",
+			from: object(openapi3.Schemas{"spec": object(openapi3.Schemas{
+				"policy": openapi3.NewStringSchema().WithEnum("Always", "Never").WithDefault("Always").NewRef(),
+				"port":   openapi3.NewIntegerSchema().NewRef(),
+			}).NewRef()}),
+			to: object(openapi3.Schemas{"spec": object(openapi3.Schemas{
+				"policy": openapi3.NewStringSchema().WithEnum("Never", "Always", "OnFailure").WithDefault("Never").NewRef(),
+				"port":   &openapi3.SchemaRef{Value: &openapi3.Schema{Type: "string", Description: "Deprecated: use ports"}},
+			}).NewRef()}),
+			want: []FieldChange{
+				{Field: "spec/policy", Change: FieldChangeEnum, From: "Always, Never", To: "Always, Never, OnFailure"},
+				{Field: "spec/policy", Change: FieldChangeDefault, From: "Always", To: "Never"},
+				{Field: "spec/port", Change: FieldChangeType, From: "integer", To: "string"},
+				{Field: "spec/port", Change: FieldChangeDeprecated, To: "Deprecated: use ports"},
+			},
+		},
+		{
+			name: "items and map values",
+			from: object(openapi3.Schemas{
+				"ports":  openapi3.NewArraySchema().WithItems(object(openapi3.Schemas{"name": openapi3.NewStringSchema().NewRef()})).NewRef(),
+				"limits": openapi3.NewObjectSchema().WithAdditionalProperties(openapi3.NewStringSchema()).NewRef(),
+			}),
+			to: object(openapi3.Schemas{
+				"ports":  openapi3.NewArraySchema().WithItems(object(openapi3.Schemas{"name": openapi3.NewIntegerSchema().NewRef()})).NewRef(),
+				"limits": openapi3.NewObjectSchema().WithAdditionalProperties(openapi3.NewIntegerSchema()).NewRef(),
+			}),
+			want: []FieldChange{
+				{Field: "limits/*", Change: FieldChangeType, From: "string", To: "integer"},
+				{Field: "ports/name", Change: FieldChangeType, From: "string", To: "integer"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffSchemas("", tt.from, tt.to, map[[2]*openapi3.Schema]bool{})
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiffSchemasRecursive(t *testing.T) {
+	from := openapi3.NewObjectSchema()
+	from.Properties = openapi3.Schemas{"not": &openapi3.SchemaRef{Value: from}}
+	to := openapi3.NewObjectSchema()
+	to.Properties = openapi3.Schemas{"not": &openapi3.SchemaRef{Value: to}, "x-added": openapi3.NewStringSchema().NewRef()}
+	got := diffSchemas("", from, to, map[[2]*openapi3.Schema]bool{})
+	assert.Equal(t, []FieldChange{{Field: "x-added", Change: FieldChangeAdded, To: "string"}}, got)
+}
+
+func TestKubeCheckerDiffSchemas(t *testing.T) {
+	specFile := filepath.Join(t.TempDir(), "swagger.json")
+	assert.NoError(t, ioutil.WriteFile(specFile, []byte(testSwaggerSpec), 0644))
+	kubeC := NewKubeCheckerImpl()
+	assert.NoError(t, kubeC.LoadFromPath("1.22", specFile, false))
+	assert.NoError(t, kubeC.LoadFromPath("1.23", specFile, false))
+	diff, err := kubeC.DiffSchemas("1.22", "v1.23.1")
+	assert.NoError(t, err)
+	assert.Equal(t, &SchemaDiff{FromRelease: "1.22", ToRelease: "1.23", RemovedKinds: []KindDiff{}, AddedKinds: []KindDiff{}, ChangedKinds: []KindDiff{}}, diff)
+}
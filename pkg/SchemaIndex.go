@@ -0,0 +1,212 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"strings"
+)
+
+const (
+	// schemaIndexMagic prefixes every schema index, it is changed whenever layout of schemaIndex changes
+	schemaIndexMagic = "kubedd-schema-index/v1\n"
+
+	componentSchemasPrefix = "#/components/schemas/"
+)
+
+// schemaIndex is what kubeSpec needs for validation, kinds along with their REST paths and schema components
+// reachable from them. References between schemas are kept by name and resolved while decoding since gob can not
+// encode cyclic schemas.
+type schemaIndex struct {
+	KindInfoMap map[string][]*KindInfo
+	Schemas     openapi3.Schemas
+	// Size is size of the spec the index was compiled from, it is kept as estimate of memory held by the spec
+	Size int64
+}
+
+func init() {
+	// concrete types held by interface{} fields of schemas, i.e; extensions, enum, default and example
+	gob.Register(json.RawMessage{})
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
+
+// IsSchemaIndex tells if data is a schema index created by CompileSchemaIndex
+func IsSchemaIndex(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(schemaIndexMagic))
+}
+
+// CompileSchemaIndex parses spec data of format, SpecFormatSwagger or SpecFormatOpenApi3, and returns a compact
+// schema index of it which is loaded without converting, validating or resolving the spec again
+func CompileSchemaIndex(data []byte, format string) ([]byte, error) {
+	k := NewKubeCheckerImpl()
+	var ks *kubeSpec
+	var err error
+	switch format {
+	case SpecFormatIndex:
+		return data, nil
+	case SpecFormatSwagger:
+		ks, err = k.parseOpenApi2(data)
+	default:
+		ks, err = k.parseOpenApi3(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return encodeSchemaIndex(ks)
+}
+
+func encodeSchemaIndex(ks *kubeSpec) ([]byte, error) {
+	index := &schemaIndex{KindInfoMap: ks.kindInfoMap, Schemas: openapi3.Schemas{}, Size: ks.size}
+	var pending []string
+	for _, kindInfos := range ks.kindInfoMap {
+		for _, ki := range kindInfos {
+			pending = append(pending, ki.ComponentKey)
+		}
+	}
+	for len(pending) > 0 {
+		key := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if _, ok := index.Schemas[key]; ok {
+			continue
+		}
+		ref, ok := ks.T.Components.Schemas[key]
+		if !ok {
+			return nil, fmt.Errorf("schema component %s not found", key)
+		}
+		var refs []string
+		index.Schemas[key] = stripSchemaRef(ref, &refs)
+		for _, name := range refs {
+			pending = append(pending, strings.TrimPrefix(name, componentSchemasPrefix))
+		}
+	}
+	var buf bytes.Buffer
+	buf.WriteString(schemaIndexMagic)
+	if err := gob.NewEncoder(&buf).Encode(index); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// stripSchemaRef returns copy of ref in which references to other components are not followed, names of referred
+// components are appended to refs
+func stripSchemaRef(ref *openapi3.SchemaRef, refs *[]string) *openapi3.SchemaRef {
+	if ref == nil {
+		return nil
+	}
+	if len(ref.Ref) > 0 {
+		*refs = append(*refs, ref.Ref)
+		return &openapi3.SchemaRef{Ref: ref.Ref}
+	}
+	if ref.Value == nil {
+		return &openapi3.SchemaRef{}
+	}
+	scm := *ref.Value
+	scm.OneOf = stripSchemaRefs(ref.Value.OneOf, refs)
+	scm.AnyOf = stripSchemaRefs(ref.Value.AnyOf, refs)
+	scm.AllOf = stripSchemaRefs(ref.Value.AllOf, refs)
+	scm.Not = stripSchemaRef(ref.Value.Not, refs)
+	scm.Items = stripSchemaRef(ref.Value.Items, refs)
+	scm.AdditionalProperties = stripSchemaRef(ref.Value.AdditionalProperties, refs)
+	if ref.Value.Properties != nil {
+		scm.Properties = make(openapi3.Schemas, len(ref.Value.Properties))
+		for name, property := range ref.Value.Properties {
+			scm.Properties[name] = stripSchemaRef(property, refs)
+		}
+	}
+	return &openapi3.SchemaRef{Value: &scm}
+}
+
+func stripSchemaRefs(schemaRefs openapi3.SchemaRefs, refs *[]string) openapi3.SchemaRefs {
+	if schemaRefs == nil {
+		return nil
+	}
+	stripped := make(openapi3.SchemaRefs, 0, len(schemaRefs))
+	for _, ref := range schemaRefs {
+		stripped = append(stripped, stripSchemaRef(ref, refs))
+	}
+	return stripped
+}
+
+func decodeSchemaIndex(data []byte) (*kubeSpec, error) {
+	if !IsSchemaIndex(data) {
+		return nil, fmt.Errorf("unsupported schema index, compile it again with this version of kubedd")
+	}
+	index := &schemaIndex{}
+	if err := gob.NewDecoder(bytes.NewReader(data[len(schemaIndexMagic):])).Decode(index); err != nil {
+		return nil, fmt.Errorf("invalid schema index: %w", err)
+	}
+	if err := resolveSchemaRefs(index.Schemas); err != nil {
+		return nil, err
+	}
+	if index.KindInfoMap == nil {
+		index.KindInfoMap = map[string][]*KindInfo{}
+	}
+	openapi := &openapi3.T{OpenAPI: "3.0.0", Components: openapi3.Components{Schemas: index.Schemas}}
+	return &kubeSpec{T: openapi, kindInfoMap: index.KindInfoMap, size: index.Size}, nil
+}
+
+// resolveSchemaRefs points references between schemas to the components they name, as openapi3.Loader does
+func resolveSchemaRefs(schemas openapi3.Schemas) error {
+	visited := map[*openapi3.Schema]bool{}
+	var resolve func(ref *openapi3.SchemaRef) error
+	resolve = func(ref *openapi3.SchemaRef) error {
+		if ref == nil {
+			return nil
+		}
+		if len(ref.Ref) > 0 {
+			target, ok := schemas[strings.TrimPrefix(ref.Ref, componentSchemasPrefix)]
+			if !ok || target == ref {
+				return fmt.Errorf("invalid schema index: unresolved reference %s", ref.Ref)
+			}
+			if target.Value == nil {
+				if err := resolve(target); err != nil {
+					return err
+				}
+			}
+			ref.Value = target.Value
+			return nil
+		}
+		if ref.Value == nil || visited[ref.Value] {
+			return nil
+		}
+		visited[ref.Value] = true
+		scm := ref.Value
+		children := append(openapi3.SchemaRefs{scm.Not, scm.Items, scm.AdditionalProperties}, scm.OneOf...)
+		children = append(append(children, scm.AnyOf...), scm.AllOf...)
+		for _, property := range scm.Properties {
+			children = append(children, property)
+		}
+		for _, child := range children {
+			if err := resolve(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, ref := range schemas {
+		if err := resolve(ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
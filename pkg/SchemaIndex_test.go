@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileSchemaIndex(t *testing.T) {
+	index, err := CompileSchemaIndex([]byte(testSwaggerSpec), SpecFormatSwagger)
+	assert.NoError(t, err)
+	assert.True(t, IsSchemaIndex(index))
+	assert.False(t, IsSchemaIndex([]byte(testSwaggerSpec)))
+
+	indexFile := filepath.Join(t.TempDir(), "1.22.index")
+	assert.NoError(t, ioutil.WriteFile(indexFile, index, 0644))
+	specFile := filepath.Join(t.TempDir(), "swagger.json")
+	assert.NoError(t, ioutil.WriteFile(specFile, []byte(testSwaggerSpec), 0644))
+
+	compiled := NewKubeCheckerImpl()
+	assert.NoError(t, compiled.LoadFromPath("1.22", indexFile, false))
+	parsed := NewKubeCheckerImpl()
+	assert.NoError(t, parsed.LoadFromPath("1.22", specFile, false))
+
+	tests := []struct {
+		name       string
+		object     string
+		wantErrors int
+	}{
+		{
+			name:   "valid deployment",
+			object: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "d"}, "spec": {"selector": {}, "replicas": 1, "maxSurge": 1}}`,
+		},
+		{
+			name:       "deployment without selector and invalid replicas",
+			object:     `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "d"}, "spec": {"replicas": "one"}}`,
+			wantErrors: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := parsed.ValidateJson(tt.object, "1.22")
+			assert.NoError(t, err)
+			got, err := compiled.ValidateJson(tt.object, "1.22")
+			assert.NoError(t, err)
+			assert.Len(t, got.ErrorsForOriginal, tt.wantErrors)
+			assert.Equal(t, len(want.ErrorsForOriginal), len(got.ErrorsForOriginal))
+			assert.Equal(t, want.LatestAPIVersion, got.LatestAPIVersion)
+		})
+	}
+	wantKinds, _ := parsed.GetKinds("1.22")
+	gotKinds, err := compiled.GetKinds("1.22")
+	assert.NoError(t, err)
+	assert.Equal(t, wantKinds, gotKinds)
+
+	_, err = decodeSchemaIndex([]byte(testSwaggerSpec))
+	assert.Error(t, err)
+}
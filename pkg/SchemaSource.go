@@ -0,0 +1,414 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/devtron-labs/silver-surfer/pkg/errors"
+	kLog "github.com/devtron-labs/silver-surfer/pkg/log"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// releaseSpecPath is the location of swagger.json relative to base url of kubernetes repository mirrors
+const releaseSpecPath = "/release-%s/api/openapi-spec/swagger.json"
+
+// SchemaSource yields openapi spec of a kubernetes release version along with its format i.e; SpecFormatSwagger or
+// SpecFormatOpenApi3. errors.ErrOpenApiSpecNotFound is returned if the source does not have the release version.
+type SchemaSource interface {
+	Name() string
+	Fetch(releaseVersion string) ([]byte, string, error)
+}
+
+// SchemaSourceOptions configures how schema sources reach remote locations
+type SchemaSourceOptions struct {
+	Headers               http.Header
+	BearerToken           string
+	CAFile                string
+	InsecureSkipTLSVerify bool
+	Cache                 *SchemaCache
+}
+
+// NewSchemaSourceOptions builds options from schema location settings of conf
+func NewSchemaSourceOptions(conf *Config) (*SchemaSourceOptions, error) {
+	opts := &SchemaSourceOptions{
+		Headers:               http.Header{},
+		BearerToken:           conf.SchemaBearerToken,
+		CAFile:                conf.SchemaCAFile,
+		InsecureSkipTLSVerify: conf.InsecureSkipTLSVerify || conf.SchemaInsecureSkipTLSVerify,
+	}
+	for _, header := range conf.SchemaHeaders {
+		parts := strings.SplitN(header, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid schema header %q, expected Name: value", header)
+		}
+		opts.Headers.Add(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+	}
+	if len(conf.CacheDir) > 0 {
+		opts.Cache = NewSchemaCache(conf.CacheDir, conf.CacheTTL, conf.RefreshSchemas)
+	}
+	return opts, nil
+}
+
+// NewSchemaSourcesForConfig returns chain of sources tried in order, embedded specs first followed by
+// conf.SchemaLocation, or upstream kubernetes repository if it is not set, and conf.AdditionalSchemaLocations
+func NewSchemaSourcesForConfig(conf *Config) ([]SchemaSource, error) {
+	opts, err := NewSchemaSourceOptions(conf)
+	if err != nil {
+		return nil, err
+	}
+	sources := []SchemaSource{&embeddedSchemaSource{}}
+	primary := conf.SchemaLocation
+	if len(primary) == 0 {
+		primary = urlTemplate
+	}
+	for _, location := range append([]string{primary}, conf.AdditionalSchemaLocations...) {
+		source, err := NewSchemaSource(location, opts)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+// NewSchemaSource returns source for location which is one of
+//   - http(s) url template in which %s is replaced by release version, or base url of a kubernetes repository
+//     mirror under which swagger.json is found at /release-<version>/api/openapi-spec/swagger.json
+//   - schema bundle i.e; tar.gz or directory with index.json
+//   - directory holding <version>.json or <version>/swagger.json files
+//   - file path template in which %s is replaced by release version
+func NewSchemaSource(location string, opts *SchemaSourceOptions) (SchemaSource, error) {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		if !strings.Contains(location, "%s") {
+			location = strings.TrimSuffix(location, "/") + releaseSpecPath
+		}
+		return newUrlSchemaSource(location, opts)
+	}
+	location = strings.TrimPrefix(location, "file://")
+	if HasBundleIndex(location) {
+		bundle, err := OpenSchemaBundle(location)
+		if err != nil {
+			return nil, err
+		}
+		return &bundleSchemaSource{bundle: bundle}, nil
+	}
+	return &fileSchemaSource{location: location}, nil
+}
+
+// urlSchemaSource downloads swagger.json from a url template, downloaded specs are persisted in cache if one is set
+type urlSchemaSource struct {
+	template string
+	header   http.Header
+	client   *http.Client
+	cache    *SchemaCache
+}
+
+func newUrlSchemaSource(template string, opts *SchemaSourceOptions) (*urlSchemaSource, error) {
+	if opts == nil {
+		opts = &SchemaSourceOptions{}
+	}
+	source := &urlSchemaSource{template: template, header: http.Header{}, cache: opts.Cache}
+	for name, values := range opts.Headers {
+		for _, value := range values {
+			source.header.Add(name, value)
+		}
+	}
+	if len(opts.BearerToken) > 0 {
+		source.header.Set("Authorization", "Bearer "+opts.BearerToken)
+	}
+	// TLS settings of the default transport are replaced as they may be relaxed for other servers
+	tlsConfig := &tls.Config{InsecureSkipVerify: opts.InsecureSkipTLSVerify}
+	if len(opts.CAFile) > 0 {
+		pem, err := ioutil.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", opts.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	source.client = &http.Client{Transport: transport}
+	return source, nil
+}
+
+func (s *urlSchemaSource) Name() string {
+	return s.template
+}
+
+func (s *urlSchemaSource) Fetch(releaseVersion string) ([]byte, string, error) {
+	if s.cache != nil {
+		return s.fetchCached(releaseVersion)
+	}
+	resp, err := s.download(releaseLocation(s.template, releaseVersion), http.Header{})
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.data, SpecFormatSwagger, nil
+}
+
+// fetchCached serves spec from cache while it is fresh, once expired it is revalidated against the origin and
+// downloaded again only if it has changed. Stale copy is used in case origin is unreachable.
+func (s *urlSchemaSource) fetchCached(releaseVersion string) ([]byte, string, error) {
+	url := releaseLocation(s.template, releaseVersion)
+	entry, err := s.cache.lookup(url)
+	if err != nil || s.cache.refresh {
+		entry = nil
+	}
+	if entry != nil && !entry.IsExpired(s.cache.ttl) {
+		if converted, err := s.readCached(entry); err == nil {
+			return converted, SpecFormatOpenApi3, nil
+		}
+	}
+	header := http.Header{}
+	if entry != nil {
+		if len(entry.ETag) > 0 {
+			header.Set("If-None-Match", entry.ETag)
+		}
+		if len(entry.LastModified) > 0 {
+			header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+	resp, err := s.download(url, header)
+	if err != nil {
+		if entry != nil && err != errors.ErrOpenApiSpecNotFound {
+			if converted, cacheErr := s.readCached(entry); cacheErr == nil {
+				kLog.Warn(fmt.Sprintf("unable to revalidate openapi-spec for %s, using cached copy: %v", releaseVersion, err))
+				return converted, SpecFormatOpenApi3, nil
+			}
+		}
+		return nil, "", err
+	}
+	if resp.notModified && entry != nil {
+		if converted, err := s.readCached(entry); err == nil {
+			_ = s.cache.touch(entry)
+			return converted, SpecFormatOpenApi3, nil
+		}
+		// cached blobs are gone, fetch the spec again
+		if resp, err = s.download(url, http.Header{}); err != nil {
+			return nil, "", err
+		}
+	}
+	converted, err := ConvertOpenApi2(resp.data)
+	if err != nil {
+		return nil, "", err
+	}
+	entry = &SchemaCacheEntry{
+		ReleaseVersion: releaseVersion,
+		Url:            url,
+		ETag:           resp.etag,
+		LastModified:   resp.lastModified,
+		FetchedAt:      time.Now(),
+	}
+	// failing to persist only costs a download next time, hence not an error
+	_ = s.cache.store(entry, resp.data, converted)
+	return converted, SpecFormatOpenApi3, nil
+}
+
+func (s *urlSchemaSource) readCached(entry *SchemaCacheEntry) ([]byte, error) {
+	converted, err := s.cache.readConverted(entry)
+	if err == nil {
+		return converted, nil
+	}
+	raw, err := s.cache.readRaw(entry)
+	if err != nil {
+		return nil, err
+	}
+	if converted, err = ConvertOpenApi2(raw); err != nil {
+		return nil, err
+	}
+	_ = s.cache.store(entry, raw, converted)
+	return converted, nil
+}
+
+// FetchOpenApi3 downloads per group-version openapi 3 documents from v3 directory next to swagger.json, i.e;
+// api/openapi-spec/v3 of kubernetes repository. Group-versions are discovered from paths of swagger.json
+func (s *urlSchemaSource) FetchOpenApi3(releaseVersion string) ([][]byte, error) {
+	swaggerUrl := releaseLocation(s.template, releaseVersion)
+	base := swaggerUrl[:strings.LastIndex(swaggerUrl, "/")+1] + "v3/"
+	var entry *SchemaCacheEntry
+	if s.cache != nil && !s.cache.refresh {
+		entry, _ = s.cache.lookup(base)
+		if entry != nil && !entry.IsExpired(s.cache.ttl) {
+			if merged, err := s.cache.readRaw(entry); err == nil {
+				return [][]byte{merged}, nil
+			}
+		}
+	}
+	data, _, err := s.Fetch(releaseVersion)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := groupVersionPaths(data)
+	if err != nil {
+		return nil, err
+	}
+	var docs [][]byte
+	for _, path := range paths {
+		resp, err := s.download(base+openApi3DocName(path), http.Header{})
+		if err == errors.ErrOpenApiSpecNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, resp.data)
+	}
+	if len(docs) == 0 {
+		return nil, errors.ErrOpenApiSpecNotFound
+	}
+	if s.cache != nil {
+		merged, err := MergeOpenApi3(docs)
+		if err != nil {
+			return nil, err
+		}
+		entry = &SchemaCacheEntry{ReleaseVersion: releaseVersion, Url: base, FetchedAt: time.Now()}
+		_ = s.cache.store(entry, merged, nil)
+		return [][]byte{merged}, nil
+	}
+	return docs, nil
+}
+
+type downloadResponse struct {
+	data         []byte
+	etag         string
+	lastModified string
+	notModified  bool
+}
+
+func (s *urlSchemaSource) download(url string, header http.Header) (*downloadResponse, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = s.header.Clone()
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		//kLog.Debug(fmt.Sprintf("%v", err))
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.ErrOpenApiSpecNotFound
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		return &downloadResponse{notModified: true}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s while downloading %s", resp.Status, url)
+	}
+	var out bytes.Buffer
+	_, err = io.Copy(&out, resp.Body)
+	if err != nil {
+		//kLog.Debug(fmt.Sprintf("%v", err))
+		return nil, err
+	}
+	return &downloadResponse{
+		data:         out.Bytes(),
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
+
+// fileSchemaSource reads swagger.json from a path template or a directory of specs
+type fileSchemaSource struct {
+	location string
+}
+
+func (s *fileSchemaSource) Name() string {
+	return s.location
+}
+
+func (s *fileSchemaSource) Fetch(releaseVersion string) ([]byte, string, error) {
+	if !strings.Contains(s.location, "%s") {
+		if info, err := os.Stat(s.location); err != nil || !info.IsDir() {
+			return nil, "", errors.ErrOpenApiSpecNotFound
+		}
+	}
+	data, err := readSwagger(s.location, releaseVersion)
+	if err != nil {
+		if os.IsNotExist(err) || !strings.Contains(s.location, "%s") {
+			return nil, "", errors.ErrOpenApiSpecNotFound
+		}
+		return nil, "", err
+	}
+	return data, SpecFormatSwagger, nil
+}
+
+// FetchOpenApi3 reads per group-version openapi 3 documents from <version>/v3 of a directory of specs, or from v3
+// directory next to swagger.json in case of a path template
+func (s *fileSchemaSource) FetchOpenApi3(releaseVersion string) ([][]byte, error) {
+	dir := filepath.Join(s.location, releaseVersion, "v3")
+	if strings.Contains(s.location, "%s") {
+		dir = filepath.Join(filepath.Dir(releaseLocation(s.location, releaseVersion)), "v3")
+	}
+	if !isOpenApi3Dir(dir) {
+		return nil, errors.ErrOpenApiSpecNotFound
+	}
+	return readOpenApi3Dir(dir)
+}
+
+type bundleSchemaSource struct {
+	bundle *SchemaBundle
+}
+
+func (s *bundleSchemaSource) Name() string {
+	return s.bundle.location
+}
+
+func (s *bundleSchemaSource) Fetch(releaseVersion string) ([]byte, string, error) {
+	if _, ok := s.bundle.Resolve(releaseVersion); !ok {
+		return nil, "", errors.ErrOpenApiSpecNotFound
+	}
+	return s.bundle.Load(releaseVersion)
+}
+
+type embeddedSchemaSource struct{}
+
+func (s *embeddedSchemaSource) Name() string {
+	return "embedded"
+}
+
+func (s *embeddedSchemaSource) Fetch(releaseVersion string) ([]byte, string, error) {
+	if !hasEmbeddedReleaseVersion(releaseVersion) {
+		return nil, "", errors.ErrOpenApiSpecNotFound
+	}
+	data, err := embeddedSpecs.Load(releaseVersion)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, SpecFormatOpenApi3, nil
+}
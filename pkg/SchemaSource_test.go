@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devtron-labs/silver-surfer/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaSourceFallbackChain(t *testing.T) {
+	var brokenRequests, mirrorRequests int
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		brokenRequests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer broken.Close()
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mirrorRequests++
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("X-Mirror") != "kubedd" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/release-1.22/api/openapi-spec/swagger.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(testSwaggerSpec))
+	}))
+	defer mirror.Close()
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "1.23"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "1.23", "swagger.json"), []byte(testSwaggerSpec), 0644))
+
+	tests := []struct {
+		name           string
+		releaseVersion string
+		wantErr        bool
+	}{
+		{name: "served by mirror after broken location fails", releaseVersion: "1.22"},
+		{name: "served by local directory", releaseVersion: "1.23"},
+		{name: "missing in every location", releaseVersion: "1.99", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kc, err := NewKubeCheckerImplForConfig(&Config{
+				SchemaLocation:            broken.URL + "/%s.json",
+				AdditionalSchemaLocations: []string{mirror.URL, dir},
+				SchemaHeaders:             []string{"X-Mirror: kubedd"},
+				SchemaBearerToken:         "secret",
+			})
+			assert.NoError(t, err)
+			err = kc.LoadFromUrl(tt.releaseVersion, false)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, kc.IsApiVersionSupported(tt.releaseVersion, "apps/v1", "Deployment"))
+		})
+	}
+	assert.Equal(t, 3, brokenRequests)
+	assert.Equal(t, 3, mirrorRequests)
+
+	// version missing in every location is reported as not found
+	kc, err := NewKubeCheckerImplForConfig(&Config{SchemaLocation: mirror.URL, AdditionalSchemaLocations: []string{dir},
+		SchemaBearerToken: "secret", SchemaHeaders: []string{"X-Mirror: kubedd"}})
+	assert.NoError(t, err)
+	assert.Equal(t, errors.ErrOpenApiSpecNotFound, kc.LoadFromUrl("1.99", false))
+
+	_, err = NewKubeCheckerImplForConfig(&Config{SchemaHeaders: []string{"invalid"}})
+	assert.Error(t, err)
+}
+
+func TestSchemaSourceInsecureSkipTLSVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testSwaggerSpec))
+	}))
+	defer server.Close()
+	tests := []struct {
+		name    string
+		conf    *Config
+		wantErr bool
+	}{
+		{name: "certificate is verified by default", conf: &Config{}, wantErr: true},
+		{name: "certificate is not verified for any server", conf: &Config{InsecureSkipTLSVerify: true}},
+		{name: "certificate is not verified for schema locations", conf: &Config{SchemaInsecureSkipTLSVerify: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.conf.SchemaLocation = server.URL + "/%s.json"
+			kc, err := NewKubeCheckerImplForConfig(tt.conf)
+			assert.NoError(t, err)
+			err = kc.LoadFromUrl("1.22", false)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -43,7 +43,16 @@ func (f ValidFormat) IsFormat(input interface{}) bool {
 // ValidationResult contains the details from
 // validating a given Kubernetes resource
 type ValidationResult struct {
-	FileName               string
+	FileName string
+	// DocumentIndex, Line and Column locate the resource in FileName, they are unset for resources fetched from a
+	// cluster
+	DocumentIndex int
+	Line          int
+	Column        int
+	// ListItemPath is the JSON path of the resource in the list document it is expanded from, eg items/3
+	ListItemPath string
+	// positions are positions of fields of the resource in FileName keyed by their JSON path
+	positions              map[string]Position
 	Kind                   string
 	APIVersion             string
 	ValidatedAgainstSchema bool
@@ -52,12 +61,31 @@ type ValidationResult struct {
 	ErrorsForLatest        []*openapi3.SchemaError
 	DeprecationForOriginal []*SchemaError
 	DeprecationForLatest   []*SchemaError
-	ResourceName           string
-	ResourceNamespace      string
-	Deleted                bool
-	Deprecated             bool
-	LatestAPIVersion       string
-	IsVersionSupported     int
+	// FieldRemovals are fields of the resource which are in its apiVersion but not in LatestAPIVersion, they are
+	// dropped silently once the resource is migrated
+	FieldRemovals []*SchemaError
+	// UnknownFields are fields of the resource which are not in schema of its apiVersion, they are reported in strict
+	// mode only
+	UnknownFields []*SchemaError
+	// BehaviourChanges are changes in behaviour of the resource once it is migrated to LatestAPIVersion although it
+	// is valid in both apiVersions
+	BehaviourChanges []*SchemaError
+	// RuleViolations are checks of custom rules the resource does not pass
+	RuleViolations     []*SchemaError
+	ResourceName       string
+	ResourceNamespace  string
+	Deleted            bool
+	Deprecated         bool
+	DeprecationWarning string
+	LatestAPIVersion   string
+	IsVersionSupported int
+	// SchemaNotFound tells that the spec has no schema for kind of the resource, eg a custom resource whose
+	// definition is not available, so the resource is not validated
+	SchemaNotFound bool
+	// DeprecatedInRelease and RemovedInRelease are the kubernetes releases in which APIVersion is deprecated and
+	// removed, they are set from ApiLifecycleTimeline of releases the resource is validated across
+	DeprecatedInRelease string
+	RemovedInRelease    string
 }
 
 type SummarySchemaError struct {
@@ -65,22 +93,60 @@ type SummarySchemaError struct {
 	SchemaField string
 	Reason      string
 	Origin      error
+	// RuleID, Severity and Remediation are set on deprecations matched by a deprecation rule
+	RuleID      string
+	Severity    string
+	Remediation string
+	// Line and Column are the position of Path in the file of the resource, or of its closest parent present in the
+	// file
+	Line   int
+	Column int
 }
 
 type SummaryValidationResult struct {
 	FileName               string
+	DocumentIndex          int
+	ListItemPath           string
+	Line                   int
+	Column                 int
 	Kind                   string
 	APIVersion             string
 	ResourceName           string
 	ResourceNamespace      string
 	Deleted                bool
 	Deprecated             bool
+	DeprecationWarning     string
 	LatestAPIVersion       string
 	IsVersionSupported     int
+	SchemaNotFound         bool
+	DeprecatedInRelease    string
+	RemovedInRelease       string
 	ErrorsForOriginal      []*SummarySchemaError
 	ErrorsForLatest        []*SummarySchemaError
 	DeprecationForOriginal []*SummarySchemaError
 	DeprecationForLatest   []*SummarySchemaError
+	FieldRemovals          []*SummarySchemaError
+	UnknownFields          []*SummarySchemaError
+	RuleViolations         []*SummarySchemaError
+	BehaviourChanges       []*SummarySchemaError
+}
+
+// SetDocument sets where the resource is, the result is of validation of the object of document
+func (v *ValidationResult) SetDocument(document Document) {
+	if len(document.FileName) > 0 {
+		v.FileName = document.FileName
+	}
+	v.DocumentIndex = document.Index
+	v.ListItemPath = document.ListItemPath
+	v.Line = document.Line
+	v.Column = document.Column
+	v.positions = document.positions
+}
+
+// PositionOf returns the position of field at path of the resource in FileName, or of its closest parent present in
+// the file, zero Position is returned if the resource is not from a file
+func (v *ValidationResult) PositionOf(path string) Position {
+	return positionOf(v.positions, path, Position{Line: v.Line, Column: v.Column})
 }
 
 // VersionKind returns a string representation of this result's apiVersion and kind
@@ -88,6 +154,25 @@ func (v *ValidationResult) VersionKind() string {
 	return v.APIVersion + "/" + v.Kind
 }
 
+// HasRuleErrors tells if the resource violates a custom rule of severity error
+func (v *ValidationResult) HasRuleErrors() bool {
+	return hasSeverity(v.RuleViolations, SeverityError)
+}
+
+// HasBehaviourChangeErrors tells if behaviour of the resource changes in a way of severity error once it is migrated
+func (v *ValidationResult) HasBehaviourChangeErrors() bool {
+	return hasSeverity(v.BehaviourChanges, SeverityError)
+}
+
+func hasSeverity(findings []*SchemaError, severity string) bool {
+	for _, finding := range findings {
+		if finding.Severity == severity {
+			return true
+		}
+	}
+	return false
+}
+
 // QualifiedName returns a string of the [namespace.]name of the k8s resource
 func (v *ValidationResult) QualifiedName() string {
 	if v.ResourceName == "" {
@@ -106,6 +191,10 @@ type SchemaError struct {
 	SchemaField string
 	Reason      string
 	Origin      error
+	// RuleID, Severity and Remediation are set on deprecations matched by a deprecation rule
+	RuleID      string
+	Severity    string
+	Remediation string
 }
 
 func markSchemaErrorKey(err error, key string) error {
@@ -232,4 +321,8 @@ type KindInfo struct {
 	RestPath     string
 	ComponentKey string
 	IsGA         bool
+	// IsStorage, Deprecated and DeprecationWarning are set for kinds of CustomResourceDefinitions only
+	IsStorage          bool
+	Deprecated         bool
+	DeprecationWarning string
 }
@@ -0,0 +1,240 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"strings"
+)
+
+const (
+	upgradePathSeparator = ".."
+
+	UpgradeStatusServed     = "served"
+	UpgradeStatusDeprecated = "deprecated"
+	UpgradeStatusRemoved    = "removed"
+	// UpgradeStatusSchemaNotFound is the status in releases whose spec has no schema for kind of the resource
+	UpgradeStatusSchemaNotFound = "schema not found"
+)
+
+// UpgradeStep is the state of a resource in one release of an upgrade path
+type UpgradeStep struct {
+	ReleaseVersion string `json:"releaseVersion"`
+	// Status is served, deprecated, removed or schema not found depending on apiVersion of the resource in the release
+	Status string `json:"status"`
+	// Blockers are fixes which must land before upgrading to the release
+	Blockers []string `json:"blockers,omitempty"`
+}
+
+// FieldDeprecation tells the first release of an upgrade path in which a field of a resource is deprecated
+type FieldDeprecation struct {
+	Field          string `json:"field"`
+	Reason         string `json:"reason"`
+	ReleaseVersion string `json:"releaseVersion"`
+}
+
+// UpgradePathResult follows a resource through every release of an upgrade path
+type UpgradePathResult struct {
+	FileName          string `json:"filename"`
+	Kind              string `json:"kind"`
+	APIVersion        string `json:"apiVersion"`
+	ResourceName      string `json:"name"`
+	ResourceNamespace string `json:"namespace"`
+	// DeprecatedInRelease is the first release in which apiVersion of the resource is deprecated
+	DeprecatedInRelease string `json:"deprecatedInRelease,omitempty"`
+	// RemovedInRelease is the first release which does not serve apiVersion of the resource
+	RemovedInRelease string `json:"removedInRelease,omitempty"`
+	// ReplacementAPIVersion is the apiVersion to migrate to before upgrading to RemovedInRelease
+	ReplacementAPIVersion string             `json:"replacementAPIVersion,omitempty"`
+	FieldDeprecations     []FieldDeprecation `json:"fieldDeprecations,omitempty"`
+	Steps                 []UpgradeStep      `json:"steps"`
+}
+
+// HasBlockers tells if any step of the upgrade path is blocked by the resource
+func (r *UpgradePathResult) HasBlockers() bool {
+	for _, step := range r.Steps {
+		if len(step.Blockers) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// HasMissingSchema tells if kind of the resource has no schema in any release of the upgrade path
+func (r *UpgradePathResult) HasMissingSchema() bool {
+	for _, step := range r.Steps {
+		if step.Status == UpgradeStatusSchemaNotFound {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseUpgradePath returns every release of an upgrade path given as <source>..<target>, one minor release at a
+// time since kubernetes can not skip minor releases while upgrading. Both ends are resolved by ResolveReleaseVersion.
+func ParseUpgradePath(path string) ([]string, error) {
+	ends := strings.Split(path, upgradePathSeparator)
+	if len(ends) != 2 {
+		return nil, fmt.Errorf("invalid upgrade path %q, expected <source>..<target> eg 1.22..1.29", path)
+	}
+	source, err := ResolveReleaseVersion(ends[0], nil)
+	if err != nil {
+		return nil, err
+	}
+	target, err := ResolveReleaseVersion(ends[1], nil)
+	if err != nil {
+		return nil, err
+	}
+	sourceMajor, sourceMinor, sourceErr := parseReleaseVersion(source)
+	targetMajor, targetMinor, targetErr := parseReleaseVersion(target)
+	if sourceErr != nil || targetErr != nil {
+		return nil, fmt.Errorf("invalid upgrade path %q, %s can not be part of an upgrade path", path, MasterReleaseVersion)
+	}
+	if sourceMajor != targetMajor || sourceMinor > targetMinor {
+		return nil, fmt.Errorf("invalid upgrade path %q, %s is not older than %s", path, source, target)
+	}
+	releases := make([]string, 0, targetMinor-sourceMinor+1)
+	for minor := sourceMinor; minor <= targetMinor; minor++ {
+		releases = append(releases, fmt.Sprintf("%d.%d", sourceMajor, minor))
+	}
+	return releases, nil
+}
+
+// AnalyzeUpgradePath summarises validation results of a resource against every release of an upgrade path, results
+// are in the same order as releases. First release is where the resource runs currently, fixes needed for it are
+// not blockers of any step.
+func AnalyzeUpgradePath(releases []string, results []ValidationResult) UpgradePathResult {
+	upgradePath := UpgradePathResult{}
+	if len(results) == 0 {
+		return upgradePath
+	}
+	first := results[0]
+	upgradePath.FileName = first.FileName
+	upgradePath.Kind = first.Kind
+	upgradePath.APIVersion = first.APIVersion
+	upgradePath.ResourceName = first.ResourceName
+	upgradePath.ResourceNamespace = first.ResourceNamespace
+
+	deprecatedFields := map[string]bool{}
+	var previous *ValidationResult
+	for i, result := range results {
+		release := releases[i]
+		step := UpgradeStep{ReleaseVersion: release, Status: UpgradeStatusServed}
+		removed := result.Deleted || result.IsVersionSupported == 2
+		if result.SchemaNotFound {
+			step.Status = UpgradeStatusSchemaNotFound
+		} else if removed {
+			step.Status = UpgradeStatusRemoved
+		} else if result.Deprecated {
+			step.Status = UpgradeStatusDeprecated
+			if len(upgradePath.DeprecatedInRelease) == 0 {
+				upgradePath.DeprecatedInRelease = release
+			}
+		}
+		for _, deprecation := range result.DeprecationForOriginal {
+			field := strings.Join(deprecation.JSONPointer(), "/")
+			if len(field) == 0 || deprecatedFields[field] {
+				continue
+			}
+			deprecatedFields[field] = true
+			upgradePath.FieldDeprecations = append(upgradePath.FieldDeprecations, FieldDeprecation{Field: field, Reason: deprecation.Reason, ReleaseVersion: release})
+		}
+		if removed && len(upgradePath.RemovedInRelease) == 0 {
+			upgradePath.RemovedInRelease = release
+			if previous != nil {
+				upgradePath.ReplacementAPIVersion = previous.LatestAPIVersion
+				step.Blockers = append(step.Blockers, migrationBlocker(upgradePath, *previous))
+			}
+		} else if !removed && previous != nil {
+			for _, e := range newSchemaErrors(previous.ErrorsForOriginal, result.ErrorsForOriginal) {
+				step.Blockers = append(step.Blockers, fmt.Sprintf("fix %s of %s %s", e, upgradePath.Kind, upgradePath.ResourceName))
+			}
+			for _, violation := range newRuleErrors(previous.RuleViolations, result.RuleViolations) {
+				step.Blockers = append(step.Blockers, fmt.Sprintf("resolve %s of %s %s (%s)", violation.RuleID, upgradePath.Kind, upgradePath.ResourceName, violation.Reason))
+			}
+		}
+		upgradePath.Steps = append(upgradePath.Steps, step)
+		previous = &results[i]
+	}
+	return upgradePath
+}
+
+// migrationBlocker describes the fix needed for apiVersion of the resource which is removed in the next release
+// given result of the release before it
+func migrationBlocker(upgradePath UpgradePathResult, previous ValidationResult) string {
+	if len(previous.LatestAPIVersion) == 0 {
+		return fmt.Sprintf("remove %s %s, %s is removed without replacement", upgradePath.Kind, upgradePath.ResourceName, upgradePath.APIVersion)
+	}
+	blocker := fmt.Sprintf("migrate %s %s from %s to %s", upgradePath.Kind, upgradePath.ResourceName, upgradePath.APIVersion, previous.LatestAPIVersion)
+	if errs := schemaErrorDescriptions(previous.ErrorsForLatest); len(errs) > 0 {
+		blocker = fmt.Sprintf("%s and fix %s", blocker, strings.Join(errs, ", "))
+	}
+	var changes []string
+	for _, change := range previous.BehaviourChanges {
+		if change.Severity == SeverityError {
+			changes = append(changes, fmt.Sprintf("%s (%s)", strings.Join(change.JSONPointer(), "/"), change.Reason))
+		}
+	}
+	if len(changes) > 0 {
+		blocker = fmt.Sprintf("%s and resolve behaviour changes of %s", blocker, strings.Join(changes, ", "))
+	}
+	return blocker
+}
+
+// newSchemaErrors returns descriptions of errors in current which are not in previous
+func newSchemaErrors(previous, current []*openapi3.SchemaError) []string {
+	known := map[string]bool{}
+	for _, description := range schemaErrorDescriptions(previous) {
+		known[description] = true
+	}
+	var errs []string
+	for _, description := range schemaErrorDescriptions(current) {
+		if !known[description] {
+			errs = append(errs, description)
+		}
+	}
+	return errs
+}
+
+// newRuleErrors returns violations of custom rules of severity error in current which are not in previous
+func newRuleErrors(previous, current []*SchemaError) []*SchemaError {
+	known := map[string]bool{}
+	for _, violation := range previous {
+		known[violation.RuleID] = true
+	}
+	var violations []*SchemaError
+	for _, violation := range current {
+		if violation.Severity == SeverityError && !known[violation.RuleID] {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+func schemaErrorDescriptions(errs []*openapi3.SchemaError) []string {
+	var descriptions []string
+	for _, e := range errs {
+		field := strings.Join(e.JSONPointer(), "/")
+		if len(field) == 0 {
+			continue
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s (%s)", field, e.Reason))
+	}
+	return descriptions
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUpgradePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "minor releases", path: "1.22..1.25", want: []string{"1.22", "1.23", "1.24", "1.25"}},
+		{name: "patch releases", path: "v1.28.3..1.29.1", want: []string{"1.28", "1.29"}},
+		{name: "same release", path: "1.29..1.29", want: []string{"1.29"}},
+		{name: "downgrade", path: "1.29..1.22", wantErr: true},
+		{name: "master", path: "1.29..master", wantErr: true},
+		{name: "missing target", path: "1.22", wantErr: true},
+		{name: "invalid version", path: "1.22..next", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseUpgradePath(tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAnalyzeUpgradePath(t *testing.T) {
+	releases := []string{"1.23", "1.24", "1.25", "1.26"}
+	resource := ValidationResult{Kind: "CronJob", APIVersion: "batch/v1beta1", ResourceName: "cj", ResourceNamespace: "apps"}
+	withResult := func(change func(result *ValidationResult)) ValidationResult {
+		result := resource
+		change(&result)
+		return result
+	}
+	deprecatedField := &SchemaError{Reason: "field is deprecated", reversePath: []string{"startingDeadlineSeconds", "spec"}}
+	ruleError := &SchemaError{Reason: "time zone must be set", RuleID: "cronjob-time-zone", Severity: SeverityError}
+	ruleWarning := &SchemaError{Reason: "suspend is set", RuleID: "cronjob-suspended", Severity: SeverityWarning}
+
+	tests := []struct {
+		name    string
+		results []ValidationResult
+		want    UpgradePathResult
+	}{
+		{
+			name: "removed after deprecation",
+			results: []ValidationResult{
+				withResult(func(r *ValidationResult) { r.LatestAPIVersion = "batch/v1" }),
+				withResult(func(r *ValidationResult) {
+					r.LatestAPIVersion = "batch/v1"
+					r.Deprecated = true
+					r.DeprecationForOriginal = []*SchemaError{deprecatedField}
+				}),
+				withResult(func(r *ValidationResult) { r.Deleted = true; r.IsVersionSupported = 2 }),
+				withResult(func(r *ValidationResult) { r.Deleted = true; r.IsVersionSupported = 2 }),
+			},
+			want: UpgradePathResult{
+				Kind: "CronJob", APIVersion: "batch/v1beta1", ResourceName: "cj", ResourceNamespace: "apps",
+				DeprecatedInRelease: "1.24", RemovedInRelease: "1.25", ReplacementAPIVersion: "batch/v1",
+				FieldDeprecations: []FieldDeprecation{{Field: "spec/startingDeadlineSeconds", Reason: "field is deprecated", ReleaseVersion: "1.24"}},
+				Steps: []UpgradeStep{
+					{ReleaseVersion: "1.23", Status: UpgradeStatusServed},
+					{ReleaseVersion: "1.24", Status: UpgradeStatusDeprecated},
+					{ReleaseVersion: "1.25", Status: UpgradeStatusRemoved, Blockers: []string{"migrate CronJob cj from batch/v1beta1 to batch/v1"}},
+					{ReleaseVersion: "1.26", Status: UpgradeStatusRemoved},
+				},
+			},
+		},
+		{
+			name: "removed without replacement",
+			results: []ValidationResult{
+				withResult(func(r *ValidationResult) {}),
+				withResult(func(r *ValidationResult) {}),
+				withResult(func(r *ValidationResult) { r.Deleted = true }),
+				withResult(func(r *ValidationResult) { r.Deleted = true }),
+			},
+			want: UpgradePathResult{
+				Kind: "CronJob", APIVersion: "batch/v1beta1", ResourceName: "cj", ResourceNamespace: "apps",
+				RemovedInRelease: "1.25",
+				Steps: []UpgradeStep{
+					{ReleaseVersion: "1.23", Status: UpgradeStatusServed},
+					{ReleaseVersion: "1.24", Status: UpgradeStatusServed},
+					{ReleaseVersion: "1.25", Status: UpgradeStatusRemoved, Blockers: []string{"remove CronJob cj, batch/v1beta1 is removed without replacement"}},
+					{ReleaseVersion: "1.26", Status: UpgradeStatusRemoved},
+				},
+			},
+		},
+		{
+			name: "already removed in the first release is not a blocker",
+			results: []ValidationResult{
+				withResult(func(r *ValidationResult) { r.Deleted = true }),
+				withResult(func(r *ValidationResult) { r.Deleted = true }),
+				withResult(func(r *ValidationResult) { r.Deleted = true }),
+				withResult(func(r *ValidationResult) { r.Deleted = true }),
+			},
+			want: UpgradePathResult{
+				Kind: "CronJob", APIVersion: "batch/v1beta1", ResourceName: "cj", ResourceNamespace: "apps",
+				RemovedInRelease: "1.23",
+				Steps: []UpgradeStep{
+					{ReleaseVersion: "1.23", Status: UpgradeStatusRemoved},
+					{ReleaseVersion: "1.24", Status: UpgradeStatusRemoved},
+					{ReleaseVersion: "1.25", Status: UpgradeStatusRemoved},
+					{ReleaseVersion: "1.26", Status: UpgradeStatusRemoved},
+				},
+			},
+		},
+		{
+			name: "schema not found is not a blocker",
+			results: []ValidationResult{
+				withResult(func(r *ValidationResult) { r.SchemaNotFound = true }),
+				withResult(func(r *ValidationResult) { r.SchemaNotFound = true }),
+				withResult(func(r *ValidationResult) { r.SchemaNotFound = true }),
+				withResult(func(r *ValidationResult) { r.SchemaNotFound = true }),
+			},
+			want: UpgradePathResult{
+				Kind: "CronJob", APIVersion: "batch/v1beta1", ResourceName: "cj", ResourceNamespace: "apps",
+				Steps: []UpgradeStep{
+					{ReleaseVersion: "1.23", Status: UpgradeStatusSchemaNotFound},
+					{ReleaseVersion: "1.24", Status: UpgradeStatusSchemaNotFound},
+					{ReleaseVersion: "1.25", Status: UpgradeStatusSchemaNotFound},
+					{ReleaseVersion: "1.26", Status: UpgradeStatusSchemaNotFound},
+				},
+			},
+		},
+		{
+			name: "new errors of custom rules are blockers",
+			results: []ValidationResult{
+				withResult(func(r *ValidationResult) { r.RuleViolations = []*SchemaError{ruleWarning} }),
+				withResult(func(r *ValidationResult) { r.RuleViolations = []*SchemaError{ruleWarning} }),
+				withResult(func(r *ValidationResult) { r.RuleViolations = []*SchemaError{ruleError, ruleWarning} }),
+				withResult(func(r *ValidationResult) { r.RuleViolations = []*SchemaError{ruleError, ruleWarning} }),
+			},
+			want: UpgradePathResult{
+				Kind: "CronJob", APIVersion: "batch/v1beta1", ResourceName: "cj", ResourceNamespace: "apps",
+				Steps: []UpgradeStep{
+					{ReleaseVersion: "1.23", Status: UpgradeStatusServed},
+					{ReleaseVersion: "1.24", Status: UpgradeStatusServed},
+					{ReleaseVersion: "1.25", Status: UpgradeStatusServed, Blockers: []string{"resolve cronjob-time-zone of CronJob cj (time zone must be set)"}},
+					{ReleaseVersion: "1.26", Status: UpgradeStatusServed},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AnalyzeUpgradePath(releases, tt.results)
+			assert.Equal(t, tt.want, got)
+			wantBlockers := false
+			for _, step := range tt.want.Steps {
+				wantBlockers = wantBlockers || len(step.Blockers) > 0
+			}
+			assert.Equal(t, wantBlockers, got.HasBlockers())
+			assert.Equal(t, tt.results[0].SchemaNotFound, got.HasMissingSchema())
+		})
+	}
+}
@@ -101,6 +101,39 @@ func compareVersion(lhs, rhs string) bool {
 	return isSmaller
 }
 
+// compareReleaseVersion returns true if lhs kubernetes release is older than rhs eg 1.9 is older than 1.16,
+// versions which cannot be parsed such as master are considered newer than the rest
+func compareReleaseVersion(lhs, rhs string) bool {
+	lhsMajor, lhsMinor, lhsErr := parseReleaseVersion(lhs)
+	rhsMajor, rhsMinor, rhsErr := parseReleaseVersion(rhs)
+	if lhsErr != nil || rhsErr != nil {
+		if lhsErr != nil && rhsErr != nil {
+			return lhs < rhs
+		}
+		return rhsErr != nil
+	}
+	if lhsMajor != rhsMajor {
+		return lhsMajor < rhsMajor
+	}
+	return lhsMinor < rhsMinor
+}
+
+func parseReleaseVersion(releaseVersion string) (int, int, error) {
+	parts := strings.Split(strings.TrimPrefix(releaseVersion, "v"), ".")
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("unable to parse release version %s", releaseVersion)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return major, minor, nil
+}
+
 func isExtension(second string) bool {
 	return strings.Index(second, "extensions") >= 0
 }
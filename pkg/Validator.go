@@ -31,6 +31,10 @@ import (
 type kubeSpec struct {
 	*openapi3.T
 	kindInfoMap map[string][]*KindInfo
+	// preferredVersions maps api groups to versions preferred by the api server, if known
+	preferredVersions map[string]string
+	// size is the size of the openapi 3 document the spec is parsed from, it bounds specs held by the registry
+	size int64
 }
 
 func newKubeSpec(openapi *openapi3.T) *kubeSpec {
@@ -39,6 +43,21 @@ func newKubeSpec(openapi *openapi3.T) *kubeSpec {
 	return ks
 }
 
+// clone returns copy of ks whose schema components and kinds can be added without affecting ks, schemas themselves
+// are shared
+func (ks *kubeSpec) clone() *kubeSpec {
+	openapi := *ks.T
+	openapi.Components.Schemas = make(openapi3.Schemas, len(ks.T.Components.Schemas))
+	for key, value := range ks.T.Components.Schemas {
+		openapi.Components.Schemas[key] = value
+	}
+	kindInfoMap := make(map[string][]*KindInfo, len(ks.kindInfoMap))
+	for kind, kindInfos := range ks.kindInfoMap {
+		kindInfoMap[kind] = append([]*KindInfo(nil), kindInfos...)
+	}
+	return &kubeSpec{T: &openapi, kindInfoMap: kindInfoMap, preferredVersions: ks.preferredVersions, size: ks.size}
+}
+
 func (ks *kubeSpec) ValidateYaml(spec string) (ValidationResult, error) {
 	var err error
 	jsonSpec, err := yaml.YAMLToJSON([]byte(spec))
@@ -62,6 +81,12 @@ func (ks *kubeSpec) ValidateJson(spec string) (ValidationResult, error) {
 
 // ks -> holds current server version of cluster , object -> target k8s version's object
 func (ks *kubeSpec) ValidateObject(object map[string]interface{}) (ValidationResult, error) {
+	return ks.validateObject(object, nil, "")
+}
+
+// validateObject validates object, fields and kinds are found deprecated by rules applying to them in releaseVersion
+// or by descriptions of their schemas if no rule applies
+func (ks *kubeSpec) validateObject(object map[string]interface{}, rules *DeprecationRules, releaseVersion string) (ValidationResult, error) {
 	validationResult, err := ks.populateValidationResult(object)
 	validationResult.ValidatedAgainstSchema = true
 	if err != nil {
@@ -74,7 +99,7 @@ func (ks *kubeSpec) ValidateObject(object map[string]interface{}) (ValidationRes
 	if len(original) > 0 {
 		var ves []*openapi3.SchemaError
 		var des []*SchemaError
-		validationError, deprecated := ks.applySchema(object, original)
+		validationError, deprecated := ks.applySchema(object, original, rules, releaseVersion)
 		if validationError != nil && len(validationError) > 0 {
 			errs := []error(validationError)
 			for _, e := range errs {
@@ -88,13 +113,17 @@ func (ks *kubeSpec) ValidateObject(object map[string]interface{}) (ValidationRes
 		validationResult.ErrorsForOriginal = ves
 		validationResult.DeprecationForOriginal = des
 		validationResult.Deprecated = deprecated
+		if ki := ks.getKindInfo(object, original); ki != nil && ki.Deprecated {
+			validationResult.Deprecated = true
+			validationResult.DeprecationWarning = ki.DeprecationWarning
+		}
 		//if original == latest {
 		//	validationResult.ErrorsForLatest = ves
 		//	validationResult.DeprecationForLatest = des
 		//}
-	} else if len(latest) == 0 { //if original and latest both are not present i.e; this has been completely removed eg psp
-		validationResult.Deleted = true
-		validationResult.IsVersionSupported = 2
+	} else if len(latest) == 0 { //kind is unknown to the spec eg custom resource without its definition, or api removed altogether eg psp which is told apart by the checker from specs of earlier releases
+		validationResult.SchemaNotFound = true
+		validationResult.ValidatedAgainstSchema = false
 	} else if len(latest) > 0 { //if original is not present but latest is then original is removed
 		validationResult.Deleted = true
 		validationResult.IsVersionSupported = 2
@@ -102,7 +131,7 @@ func (ks *kubeSpec) ValidateObject(object map[string]interface{}) (ValidationRes
 	if len(latest) > 0 && original != latest { //compute only if latest is different from original i.e; newer version is available
 		var ves []*openapi3.SchemaError
 		var des []*SchemaError
-		validationError, _ := ks.applySchema(object, latest)
+		validationError, _ := ks.applySchema(object, latest, rules, releaseVersion)
 		if validationError != nil && len(validationError) > 0 {
 			errs := []error(validationError)
 			for _, e := range errs {
@@ -116,10 +145,67 @@ func (ks *kubeSpec) ValidateObject(object map[string]interface{}) (ValidationRes
 		validationResult.ErrorsForLatest = ves
 		validationResult.DeprecationForLatest = des
 		validationResult.LatestAPIVersion, err = ks.getKeyForGVFromToken(latest)
+		if len(original) > 0 {
+			validationResult.FieldRemovals = ks.fieldRemovals(object, original, latest, validationResult.LatestAPIVersion)
+		}
+		validationResult.BehaviourChanges = behaviourChanges(object, validationResult.Kind, validationResult.APIVersion, validationResult.LatestAPIVersion)
 	}
 	return validationResult, nil
 }
 
+// typeMetaFields are fields at the root of every kubernetes object, schemas of custom resources may leave them out
+var typeMetaFields = map[string]bool{"apiVersion": true, "kind": true, "metadata": true}
+
+// unknownFields returns fields of object which are not in schema of component original, as kubectl apply
+// --validate=strict would reject them
+func (ks *kubeSpec) unknownFields(object map[string]interface{}, original string) []*SchemaError {
+	scm, err := ks.schemaLookup(original)
+	if err != nil {
+		return nil
+	}
+	var unknown []*SchemaError
+	for _, e := range visitUnknownFields(scm, object) {
+		se, ok := e.(*SchemaError)
+		if !ok {
+			continue
+		}
+		path := se.JSONPointer()
+		if len(path) == 1 && typeMetaFields[path[0]] {
+			continue
+		}
+		se.Reason = fmt.Sprintf("unknown field %q", strings.Join(path, "."))
+		unknown = append(unknown, se)
+	}
+	sort.Slice(unknown, func(i, j int) bool {
+		return strings.Join(unknown[i].JSONPointer(), "/") < strings.Join(unknown[j].JSONPointer(), "/")
+	})
+	return unknown
+}
+
+// fieldRemovals returns fields of object which are in schema of component original but not in that of latest
+func (ks *kubeSpec) fieldRemovals(object map[string]interface{}, original, latest, latestAPIVersion string) []*SchemaError {
+	originalScm, err := ks.schemaLookup(original)
+	if err != nil {
+		return nil
+	}
+	latestScm, err := ks.schemaLookup(latest)
+	if err != nil {
+		return nil
+	}
+	apiVersion, _ := object["apiVersion"].(string)
+	var removals []*SchemaError
+	for _, e := range visitFieldRemovals(originalScm, latestScm, object) {
+		if se, ok := e.(*SchemaError); ok {
+			se.Reason = fmt.Sprintf("field %s exists in %s but not in %s", strings.Join(se.JSONPointer(), "/"), apiVersion, latestAPIVersion)
+			removals = append(removals, se)
+		}
+	}
+	sort.Slice(removals, func(i, j int) bool {
+		return strings.Join(removals[i].JSONPointer(), "/") < strings.Join(removals[j].JSONPointer(), "/")
+	})
+	return removals
+}
+
 // buildGVKRestPathMap goes through openApi3 spec of specified k8s version and prepares map of gvk, and it's api-server path
 func (ks *kubeSpec) buildGVKRestPathMap() map[string]string {
 	pathMap := map[string]string{}
@@ -251,7 +337,7 @@ func (ks *kubeSpec) populateValidationResult(object map[string]interface{}) (Val
 	return validationResult, nil
 }
 
-func (ks *kubeSpec) applySchema(object map[string]interface{}, token string) (openapi3.MultiError, bool) {
+func (ks *kubeSpec) applySchema(object map[string]interface{}, token string, rules *DeprecationRules, releaseVersion string) (openapi3.MultiError, bool) {
 	deprecated := false
 	var validationError openapi3.MultiError
 	scm, err := ks.schemaLookup(token)
@@ -262,8 +348,13 @@ func (ks *kubeSpec) applySchema(object map[string]interface{}, token string) (op
 	}
 
 	opts := []openapi3.SchemaValidationOption{openapi3.MultiErrors()}
-	depError := VisitJSON(scm, object, SchemaSettings{MultiError: true})
-	if strings.Index(strings.ToLower(scm.Description), "deprecated") >= 0 {
+	deprecations := &deprecationCheck{rules: rules, releaseVersion: releaseVersion}
+	deprecations.kind, _ = object["kind"].(string)
+	if ki := ks.getKindInfo(object, token); ki != nil {
+		deprecations.apiVersion = groupVersion(ki.Group, ki.Version)
+	}
+	depError := visitJSON(scm, object, SchemaSettings{MultiError: true, deprecations: deprecations}, nil)
+	if deprecations.isDeprecatedKind(scm) {
 		deprecated = true
 	}
 	validationError = append(validationError, depError...)
@@ -332,9 +423,45 @@ func (ks *kubeSpec) getKindsMappings(object map[string]interface{}) (original, l
 				original = ki.ComponentKey
 			}
 		}
-		if len(kis) > 0 { // most resent entry is the latest one
-			latest = kis[len(kis)-1].ComponentKey
+		if ki := ks.replacementKindInfo(parts[0], kind); ki != nil {
+			latest = ki.ComponentKey
 		}
 	}
 	return original, latest, nil
 }
+
+// getKindInfo returns kind info of object's kind having componentKey
+func (ks *kubeSpec) getKindInfo(object map[string]interface{}, componentKey string) *KindInfo {
+	kind, _ := object["kind"].(string)
+	for _, ki := range ks.kindInfoMap[strings.ToLower(kind)] {
+		if ki.ComponentKey == componentKey {
+			return ki
+		}
+	}
+	return nil
+}
+
+// MergeSourceValidationResult returns target, the result of validating an object against the release to upgrade to,
+// with its original side taken from source, the result of validating the object against the release it runs on
+// today. Deprecations found only in target, fields deprecated in between for instance, are kept. Target is returned
+// as it is if apiVersion of the object is not served in the source release either.
+func MergeSourceValidationResult(target, source ValidationResult) ValidationResult {
+	if len(source.Kind) == 0 || source.SchemaNotFound || source.Deleted {
+		return target
+	}
+	target.ErrorsForOriginal = source.ErrorsForOriginal
+	target.UnknownFields = source.UnknownFields
+	deprecations := source.DeprecationForOriginal
+	known := map[string]bool{}
+	for _, deprecation := range deprecations {
+		known[strings.Join(deprecation.JSONPointer(), "/")] = true
+	}
+	for _, deprecation := range target.DeprecationForOriginal {
+		if path := strings.Join(deprecation.JSONPointer(), "/"); !known[path] {
+			known[path] = true
+			deprecations = append(deprecations, deprecation)
+		}
+	}
+	target.DeprecationForOriginal = deprecations
+	return target
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSourceValidationResult(t *testing.T) {
+	sourceError := &openapi3.SchemaError{Reason: "source error"}
+	targetError := &openapi3.SchemaError{Reason: "target error"}
+	fieldDeprecation := func(path ...string) *SchemaError {
+		return &SchemaError{Reason: "deprecated", reversePath: reversed(path)}
+	}
+	tests := []struct {
+		name                 string
+		target               ValidationResult
+		source               ValidationResult
+		wantErrors           []*openapi3.SchemaError
+		wantDeprecationPaths []string
+	}{
+		{
+			name: "original side from source",
+			target: ValidationResult{Kind: "Deployment", ErrorsForOriginal: []*openapi3.SchemaError{targetError},
+				DeprecationForOriginal: []*SchemaError{fieldDeprecation("spec", "a"), fieldDeprecation("spec", "b")}},
+			source: ValidationResult{Kind: "Deployment", ErrorsForOriginal: []*openapi3.SchemaError{sourceError},
+				DeprecationForOriginal: []*SchemaError{fieldDeprecation("spec", "a")}},
+			wantErrors:           []*openapi3.SchemaError{sourceError},
+			wantDeprecationPaths: []string{"spec/a", "spec/b"},
+		},
+		{
+			name:                 "api version removed in target",
+			target:               ValidationResult{Kind: "Ingress", Deleted: true, LatestAPIVersion: "networking.k8s.io/v1"},
+			source:               ValidationResult{Kind: "Ingress", ErrorsForOriginal: []*openapi3.SchemaError{sourceError}, DeprecationForOriginal: []*SchemaError{fieldDeprecation("spec", "backend")}},
+			wantErrors:           []*openapi3.SchemaError{sourceError},
+			wantDeprecationPaths: []string{"spec/backend"},
+		},
+		{
+			name:       "api version not served in source",
+			target:     ValidationResult{Kind: "Ingress", ErrorsForOriginal: []*openapi3.SchemaError{targetError}},
+			source:     ValidationResult{Kind: "Ingress", Deleted: true},
+			wantErrors: []*openapi3.SchemaError{targetError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeSourceValidationResult(tt.target, tt.source)
+			assert.Equal(t, tt.wantErrors, got.ErrorsForOriginal)
+			var paths []string
+			for _, deprecation := range got.DeprecationForOriginal {
+				paths = append(paths, strings.Join(deprecation.JSONPointer(), "/"))
+			}
+			assert.Equal(t, tt.wantDeprecationPaths, paths)
+			assert.Equal(t, tt.target.Deleted, got.Deleted)
+			assert.Equal(t, tt.target.LatestAPIVersion, got.LatestAPIVersion)
+		})
+	}
+}
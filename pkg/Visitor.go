@@ -18,36 +18,35 @@
 package pkg
 
 import (
+	"encoding/json"
 	"github.com/getkin/kin-openapi/openapi3"
-	"strings"
+	"strconv"
 )
 
 type SchemaSettings struct {
 	MultiError bool
+	// deprecations tells deprecated fields apart, descriptions of their schemas do if it is nil
+	deprecations *deprecationCheck
 }
 
 func VisitJSON(schema *openapi3.Schema, value interface{}, settings SchemaSettings) openapi3.MultiError {
-	return visitJSON(schema, value, settings)
+	return visitJSON(schema, value, settings, nil)
 }
 
-// scm-> curr version and value -> target
-func visitJSON(schema *openapi3.Schema, value interface{}, settings SchemaSettings) openapi3.MultiError {
+// scm-> curr version and value -> target, path is that of value in the object visited
+func visitJSON(schema *openapi3.Schema, value interface{}, settings SchemaSettings, path []string) openapi3.MultiError {
 	var me openapi3.MultiError
+	schema = effectiveSchema(schema)
 	switch value := value.(type) {
 	case nil, bool, float64, string, int64:
-		if strings.Index(strings.ToLower(schema.Description), "deprecated") >= 0 {
-			schemaError := &SchemaError{
-				Value:  "",
-				Schema: schema,
-				Reason: schema.Description,
-			}
+		if schemaError := settings.deprecations.fieldFinding(path, schema); schemaError != nil {
 			me = append(me, schemaError)
 		}
 		return me
 	case []interface{}:
-		return visitJSONArray(schema, value, settings)
+		return visitJSONArray(schema, value, settings, path)
 	case map[string]interface{}:
-		return visitJSONObject(schema, value, settings)
+		return visitJSONObject(schema, value, settings, path)
 	default:
 		schemaError := &SchemaError{
 			Value:  value,
@@ -59,10 +58,13 @@ func visitJSON(schema *openapi3.Schema, value interface{}, settings SchemaSettin
 	}
 }
 
-func visitJSONArray(schema *openapi3.Schema, object []interface{}, settings SchemaSettings) openapi3.MultiError {
+func visitJSONArray(schema *openapi3.Schema, object []interface{}, settings SchemaSettings, path []string) openapi3.MultiError {
 	var me openapi3.MultiError
+	if schema.Items == nil || schema.Items.Value == nil { // not an array in the schema, schema validation reports it
+		return me
+	}
 	for i, obj := range object {
-		schemaError := visitJSON(schema.Items.Value, obj, settings)
+		schemaError := visitJSON(schema.Items.Value, obj, settings, append(path[:len(path):len(path)], strconv.Itoa(i)))
 		if len(schemaError) != 0 {
 			markSchemaErrorIndex(schemaError, i)
 			me = append(me, schemaError...)
@@ -71,14 +73,9 @@ func visitJSONArray(schema *openapi3.Schema, object []interface{}, settings Sche
 	return me
 }
 
-func visitJSONObject(schema *openapi3.Schema, object map[string]interface{}, settings SchemaSettings) openapi3.MultiError {
+func visitJSONObject(schema *openapi3.Schema, object map[string]interface{}, settings SchemaSettings, path []string) openapi3.MultiError {
 	var me openapi3.MultiError
-	if strings.Index(strings.ToLower(schema.Description), "deprecated") >= 0 {
-		schemaError := &SchemaError{
-			Value:  "",
-			Schema: schema,
-			Reason: schema.Description,
-		}
+	if schemaError := settings.deprecations.fieldFinding(path, schema); schemaError != nil {
 		me = append(me, schemaError)
 		if !settings.MultiError {
 			return me
@@ -87,7 +84,7 @@ func visitJSONObject(schema *openapi3.Schema, object map[string]interface{}, set
 	for k, v := range object {
 		if s, ok := schema.Properties[k]; ok {
 			//fmt.Printf("found key %s\n", k)
-			schemaError := visitJSON(s.Value, v, settings)
+			schemaError := visitJSON(s.Value, v, settings, append(path[:len(path):len(path)], k))
 			if len(schemaError) != 0 {
 				markSchemaErrorKey(schemaError, k)
 				me = append(me, schemaError...)
@@ -99,3 +96,115 @@ func visitJSONObject(schema *openapi3.Schema, object map[string]interface{}, set
 	}
 	return me
 }
+
+// effectiveSchema returns schema referred to by scm if scm just wraps a reference in allOf, as openapi 3 specs of
+// kubernetes do to set description and default of a field alongside a reference
+func effectiveSchema(scm *openapi3.Schema) *openapi3.Schema {
+	if len(scm.AllOf) == 1 && scm.AllOf[0].Value != nil && len(scm.Type) == 0 && len(scm.Properties) == 0 {
+		wrapped := *scm.AllOf[0].Value
+		if len(scm.Description) > 0 {
+			wrapped.Description = scm.Description
+		}
+		if scm.Default != nil {
+			wrapped.Default = scm.Default
+		}
+		return &wrapped
+	}
+	return scm
+}
+
+// visitFieldRemovals returns errors for fields of value which are in schema original but not in schema latest, i.e;
+// fields which are dropped once value is migrated from apiVersion of original to that of latest
+func visitFieldRemovals(original, latest *openapi3.Schema, value interface{}) openapi3.MultiError {
+	var me openapi3.MultiError
+	original, latest = effectiveSchema(original), effectiveSchema(latest)
+	switch value := value.(type) {
+	case []interface{}:
+		if original.Items == nil || latest.Items == nil || original.Items.Value == nil || latest.Items.Value == nil {
+			return me
+		}
+		for i, item := range value {
+			schemaError := visitFieldRemovals(original.Items.Value, latest.Items.Value, item)
+			if len(schemaError) != 0 {
+				markSchemaErrorIndex(schemaError, i)
+				me = append(me, schemaError...)
+			}
+		}
+	case map[string]interface{}:
+		for k, v := range value {
+			var schemaError openapi3.MultiError
+			if op, ok := original.Properties[k]; ok && op.Value != nil {
+				lp, ok := latest.Properties[k]
+				if !ok {
+					schemaError = openapi3.MultiError{&SchemaError{Value: v, Schema: op.Value, SchemaField: k}}
+				} else if lp.Value != nil {
+					schemaError = visitFieldRemovals(op.Value, lp.Value, v)
+				}
+			} else if original.AdditionalProperties != nil && latest.AdditionalProperties != nil &&
+				original.AdditionalProperties.Value != nil && latest.AdditionalProperties.Value != nil {
+				schemaError = visitFieldRemovals(original.AdditionalProperties.Value, latest.AdditionalProperties.Value, v)
+			}
+			if len(schemaError) != 0 {
+				markSchemaErrorKey(schemaError, k)
+				me = append(me, schemaError...)
+			}
+		}
+	}
+	return me
+}
+
+// visitUnknownFields returns errors for fields of value which are not in schema, fields of objects which preserve
+// unknown fields, of free form objects such as RawExtension and values of maps are not checked
+func visitUnknownFields(schema *openapi3.Schema, value interface{}) openapi3.MultiError {
+	var me openapi3.MultiError
+	schema = effectiveSchema(schema)
+	if preservesUnknownFields(schema) {
+		return me
+	}
+	switch value := value.(type) {
+	case []interface{}:
+		if schema.Items == nil || schema.Items.Value == nil {
+			return me
+		}
+		for i, item := range value {
+			schemaError := visitUnknownFields(schema.Items.Value, item)
+			if len(schemaError) != 0 {
+				markSchemaErrorIndex(schemaError, i)
+				me = append(me, schemaError...)
+			}
+		}
+	case map[string]interface{}:
+		if len(schema.Properties) == 0 && schema.AdditionalProperties == nil {
+			return me
+		}
+		for k, v := range value {
+			var schemaError openapi3.MultiError
+			if s, ok := schema.Properties[k]; ok {
+				if s.Value != nil {
+					schemaError = visitUnknownFields(s.Value, v)
+				}
+			} else if schema.AdditionalProperties != nil {
+				if schema.AdditionalProperties.Value != nil {
+					schemaError = visitUnknownFields(schema.AdditionalProperties.Value, v)
+				}
+			} else if schema.AdditionalPropertiesAllowed == nil || !*schema.AdditionalPropertiesAllowed {
+				schemaError = openapi3.MultiError{&SchemaError{Value: v, Schema: schema, SchemaField: k}}
+			}
+			if len(schemaError) != 0 {
+				markSchemaErrorKey(schemaError, k)
+				me = append(me, schemaError...)
+			}
+		}
+	}
+	return me
+}
+
+// preservesUnknownFields tells if schema is marked with x-kubernetes-preserve-unknown-fields
+func preservesUnknownFields(schema *openapi3.Schema) bool {
+	raw, ok := schema.Extensions["x-kubernetes-preserve-unknown-fields"].(json.RawMessage)
+	if !ok {
+		return false
+	}
+	var preserve bool
+	return json.Unmarshal(raw, &preserve) == nil && preserve
+}
@@ -0,0 +1,260 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pkg
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+const testGadgetCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: gadgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Gadget
+    plural: gadgets
+  scope: Namespaced
+  versions:
+  - name: v1beta1
+    served: true
+    storage: false
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            properties:
+              size:
+                type: integer
+              legacyMode:
+                type: boolean
+              ports:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    name:
+                      type: string
+                    hostIP:
+                      type: string
+              labels:
+                type: object
+                additionalProperties:
+                  type: object
+                  properties:
+                    value:
+                      type: string
+                    weight:
+                      type: integer
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            properties:
+              size:
+                type: integer
+              ports:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    name:
+                      type: string
+              labels:
+                type: object
+                additionalProperties:
+                  type: object
+                  properties:
+                    value:
+                      type: string
+`
+
+func TestFieldRemovals(t *testing.T) {
+	crd := map[string]interface{}{}
+	assert.NoError(t, yaml.Unmarshal([]byte(testGadgetCRD), &crd))
+	specFile := filepath.Join(t.TempDir(), "swagger.json")
+	assert.NoError(t, ioutil.WriteFile(specFile, []byte(testSwaggerSpec), 0644))
+	kc := NewKubeCheckerImpl()
+	assert.NoError(t, kc.LoadFromPath("1.22", specFile, false))
+	assert.NoError(t, kc.AddCustomResourceDefinition(crd))
+
+	tests := []struct {
+		name        string
+		gadget      string
+		wantFields  []string
+		wantReasons []string
+	}{
+		{
+			name:   "fields of latest version",
+			gadget: `{"apiVersion": "example.com/v1", "kind": "Gadget", "metadata": {"name": "g"}, "spec": {"size": 1, "legacyMode": true}}`,
+		},
+		{
+			name:   "no removed field is set",
+			gadget: `{"apiVersion": "example.com/v1beta1", "kind": "Gadget", "metadata": {"name": "g"}, "spec": {"size": 1, "ports": [{"name": "http"}]}}`,
+		},
+		{
+			name: "removed fields of objects, arrays and maps",
+			gadget: `{"apiVersion": "example.com/v1beta1", "kind": "Gadget", "metadata": {"name": "g"}, "spec": {"size": 1,
+				"legacyMode": true, "ports": [{"name": "http"}, {"name": "https", "hostIP": "0.0.0.0"}],
+				"labels": {"tier": {"value": "web", "weight": 2}}}}`,
+			wantFields:  []string{"spec/labels/tier/weight", "spec/legacyMode", "spec/ports/1/hostIP"},
+			wantReasons: []string{"field spec/labels/tier/weight exists in example.com/v1beta1 but not in example.com/v1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := kc.ValidateJson(tt.gadget, "1.22")
+			assert.NoError(t, err)
+			var fields, reasons []string
+			for _, e := range result.FieldRemovals {
+				fields = append(fields, strings.Join(e.JSONPointer(), "/"))
+				reasons = append(reasons, e.Reason)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+			if len(tt.wantReasons) > 0 {
+				assert.Subset(t, reasons, tt.wantReasons)
+			}
+		})
+	}
+}
+
+const testSprocketCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: sprockets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Sprocket
+    plural: sprockets
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            properties:
+              image:
+                type: string
+              containers:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    name:
+                      type: string
+              selector:
+                type: object
+                additionalProperties:
+                  type: string
+              config:
+                type: object
+                x-kubernetes-preserve-unknown-fields: true
+              raw:
+                type: object
+`
+
+func TestUnknownFields(t *testing.T) {
+	crd := map[string]interface{}{}
+	assert.NoError(t, yaml.Unmarshal([]byte(testSprocketCRD), &crd))
+	specFile := filepath.Join(t.TempDir(), "swagger.json")
+	assert.NoError(t, ioutil.WriteFile(specFile, []byte(testSwaggerSpec), 0644))
+
+	tests := []struct {
+		name        string
+		strict      bool
+		sprocket    string
+		wantFields  []string
+		wantReasons []string
+	}{
+		{
+			name:   "known fields",
+			strict: true,
+			sprocket: `{"apiVersion": "example.com/v1", "kind": "Sprocket", "metadata": {"name": "w"}, "spec": {"image": "nginx",
+				"containers": [{"name": "web"}]}}`,
+		},
+		{
+			name:     "unknown fields are not reported unless strict",
+			sprocket: `{"apiVersion": "example.com/v1", "kind": "Sprocket", "metadata": {"name": "w"}, "spec": {"imag": "nginx"}}`,
+		},
+		{
+			name:   "unknown fields of objects and arrays",
+			strict: true,
+			sprocket: `{"apiVersion": "example.com/v1", "kind": "Sprocket", "metadata": {"name": "w"}, "spec": {"imag": "nginx",
+				"containers": [{"name": "web"}, {"name": "sidecar", "imagePullPolicys": "Always"}]}, "extra": true}`,
+			wantFields:  []string{"extra", "spec/containers/1/imagePullPolicys", "spec/imag"},
+			wantReasons: []string{`unknown field "spec.imag"`},
+		},
+		{
+			name:   "maps, preserved unknown fields and free form objects",
+			strict: true,
+			sprocket: `{"apiVersion": "example.com/v1", "kind": "Sprocket", "metadata": {"name": "w"}, "spec": {
+				"selector": {"app": "web"}, "config": {"any": {"thing": 1}}, "raw": {"kind": "Pod"}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kc := NewKubeCheckerImpl()
+			kc.strict = tt.strict
+			assert.NoError(t, kc.LoadFromPath("1.22", specFile, false))
+			assert.NoError(t, kc.AddCustomResourceDefinition(crd))
+			result, err := kc.ValidateJson(tt.sprocket, "1.22")
+			assert.NoError(t, err)
+			var fields, reasons []string
+			for _, e := range result.UnknownFields {
+				fields = append(fields, strings.Join(e.JSONPointer(), "/"))
+				reasons = append(reasons, e.Reason)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+			if len(tt.wantReasons) > 0 {
+				assert.Subset(t, reasons, tt.wantReasons)
+			}
+		})
+	}
+}
+
+func TestVisitJSONArrayWithoutItems(t *testing.T) {
+	schema := openapi3.NewObjectSchema().WithProperty("ports", openapi3.NewStringSchema())
+	value := map[string]interface{}{"ports": []interface{}{map[string]interface{}{"name": "http"}}}
+	// schema validation reports ports not being a string, visiting it is skipped
+	assert.NotPanics(t, func() {
+		assert.Empty(t, VisitJSON(schema, value, SchemaSettings{MultiError: true}))
+	})
+}
@@ -2,8 +2,32 @@ package errors
 
 import (
 	"errors"
+	"fmt"
 )
 
 const OpenApiSpecNotFoundError = "openapi-spec not found for the k8s version %s"
 
 var ErrOpenApiSpecNotFound = errors.New(OpenApiSpecNotFoundError)
+
+var (
+	// ErrInvalidReleaseVersion is wrapped by ReleaseVersionError if version is neither a kubernetes version nor an alias
+	ErrInvalidReleaseVersion = errors.New("invalid kubernetes version")
+	// ErrUnknownReleaseVersion is wrapped by ReleaseVersionError if an alias such as latest-3 refers to a release
+	// before the first kubernetes release
+	ErrUnknownReleaseVersion = errors.New("unknown kubernetes version")
+)
+
+// ReleaseVersionError is returned for kubernetes versions which can not be resolved to a release, use errors.Is with
+// ErrInvalidReleaseVersion or ErrUnknownReleaseVersion to tell the reason
+type ReleaseVersionError struct {
+	Version string
+	Err     error
+}
+
+func (e *ReleaseVersionError) Error() string {
+	return fmt.Sprintf("%v %q", e.Err, e.Version)
+}
+
+func (e *ReleaseVersionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,25 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package schemas bundles openapi specs of kubernetes releases into the binary when built with the offline tag,
+// specs are generated into data directory by running go generate before the build
+//
+//	go generate ./pkg/schemas
+//	go build -tags offline .
+package schemas
+
+//go:generate go run generate.go -out data
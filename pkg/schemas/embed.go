@@ -0,0 +1,69 @@
+//go:build offline
+
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package schemas
+
+import (
+	"compress/gzip"
+	"embed"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/devtron-labs/silver-surfer/pkg"
+)
+
+const specSuffix = ".json.gz"
+
+//go:embed data
+var data embed.FS
+
+type bundledSpecs struct{}
+
+func (b bundledSpecs) ReleaseVersions() []string {
+	entries, err := data.ReadDir("data")
+	if err != nil {
+		return nil
+	}
+	var versions []string
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), specSuffix) {
+			versions = append(versions, strings.TrimSuffix(entry.Name(), specSuffix))
+		}
+	}
+	return versions
+}
+
+func (b bundledSpecs) Load(releaseVersion string) ([]byte, error) {
+	f, err := data.Open(path.Join("data", releaseVersion+specSuffix))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	return ioutil.ReadAll(gz)
+}
+
+func init() {
+	pkg.RegisterEmbeddedSpecs(bundledSpecs{})
+}
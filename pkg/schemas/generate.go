@@ -0,0 +1,91 @@
+//go:build ignore
+
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// generate downloads openapi specs of the given kubernetes releases, converts them to openapi 3 and writes them
+// gzipped to the output directory from where they are embedded into the binary
+package main
+
+import (
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devtron-labs/silver-surfer/pkg"
+)
+
+const defaultVersions = "1.16,1.17,1.18,1.19,1.20,1.21,1.22,1.23,1.24,1.25,1.26,1.27,1.28,1.29"
+
+func main() {
+	versions := flag.String("versions", defaultVersions, "comma-separated list of kubernetes release versions to bundle")
+	urlTemplate := flag.String("url-template", "https://raw.githubusercontent.com/kubernetes/kubernetes/release-%s/api/openapi-spec/swagger.json", "location of swagger.json, %s is replaced by the release version")
+	out := flag.String("out", "data", "directory in which specs are written")
+	flag.Parse()
+
+	for _, version := range strings.Split(*versions, ",") {
+		version = strings.TrimSpace(version)
+		if len(version) == 0 {
+			continue
+		}
+		if err := bundle(version, *urlTemplate, *out); err != nil {
+			fmt.Fprintf(os.Stderr, "unable to bundle %s: %v\n", version, err)
+			os.Exit(1)
+		}
+		fmt.Printf("bundled openapi-spec for %s\n", version)
+	}
+}
+
+func bundle(version, urlTemplate, out string) error {
+	resp, err := http.Get(fmt.Sprintf(urlTemplate, version))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	converted, err := pkg.ConvertOpenApi2(data)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(out, 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(filepath.Join(out, version+".json.gz"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewWriterLevel(f, gzip.BestCompression)
+	if err != nil {
+		return err
+	}
+	if _, err := gz.Write(converted); err != nil {
+		return err
+	}
+	return gz.Close()
+}
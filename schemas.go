@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/devtron-labs/silver-surfer/pkg"
+	log2 "github.com/devtron-labs/silver-surfer/pkg/log"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+)
+
+var (
+	packVersions = make([]string, 0)
+	packFrom     = ""
+	packOutput   = ""
+	packAliases  = make(map[string]string)
+	packCompile  = false
+	packConfig   = &pkg.Config{}
+
+	schemasCmd = &cobra.Command{
+		Use:   "schemas",
+		Short: "Manage schema bundles holding openapi specs of many kubernetes versions",
+	}
+
+	schemasPackCmd = &cobra.Command{
+		Use:   "pack",
+		Short: "Pack openapi specs of kubernetes versions into a schema bundle usable as schema location",
+		Long: `Pack openapi specs of kubernetes versions into a schema bundle usable as --source-schema-location or
+--target-schema-location. Bundle is written as tar.gz archive if output ends with .tar.gz or .tgz otherwise as
+directory, specs are read from an existing bundle, a directory holding <version>.json or <version>/swagger.json
+files, or a url/path template in which %s is replaced by the kubernetes version. With --compile specs are packed
+as precompiled schema indexes which load in milliseconds instead of seconds.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(packVersions) == 0 {
+				log2.Error(errors.New("at least one kubernetes version should be passed in --versions"))
+				os.Exit(1)
+			}
+			opts, err := pkg.NewSchemaSourceOptions(packConfig)
+			if err != nil {
+				log2.Error(err)
+				os.Exit(1)
+			}
+			index, err := pkg.PackSchemaBundle(packVersions, packFrom, packOutput, packAliases, packCompile, opts)
+			if err != nil {
+				log2.Error(err)
+				os.Exit(1)
+			}
+			aliases := make([]string, 0, len(index.Aliases))
+			for alias, version := range index.Aliases {
+				aliases = append(aliases, fmt.Sprintf("%s=%s", alias, version))
+			}
+			sort.Strings(aliases)
+			fmt.Printf("packed %d kubernetes version(s) into %s, aliases %v\n", len(index.Versions), packOutput, aliases)
+		},
+	}
+)
+
+func init() {
+	schemasPackCmd.Flags().StringSliceVarP(&packVersions, "versions", "", []string{}, "A comma-separated list of kubernetes versions to pack eg 1.22,1.25,1.29")
+	schemasPackCmd.Flags().StringVarP(&packFrom, "from", "", "https://raw.githubusercontent.com/kubernetes/kubernetes/release-%s/api/openapi-spec/swagger.json", "Schema bundle, directory or url/path template with %s in place of kubernetes version to read openapi specs from")
+	schemasPackCmd.Flags().StringVarP(&packOutput, "output", "o", "schemas.tar.gz", "Schema bundle to be written, a directory unless it ends with .tar.gz or .tgz")
+	schemasPackCmd.Flags().StringToStringVarP(&packAliases, "alias", "", map[string]string{}, "Additional aliases for packed kubernetes versions eg stable=1.28, latest is always set to the highest version")
+	schemasPackCmd.Flags().BoolVar(&packCompile, "compile", false, "Pack specs as precompiled schema indexes holding only what is needed for validation, which load much faster")
+	pkg.AddSchemaClientFlags(schemasPackCmd, packConfig)
+	schemasCmd.AddCommand(schemasPackCmd)
+	RootCmd.AddCommand(schemasCmd)
+}
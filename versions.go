@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"fmt"
+	"github.com/devtron-labs/silver-surfer/pkg"
+	"github.com/spf13/cobra"
+	// registers openapi specs bundled into the binary in offline builds
+	_ "github.com/devtron-labs/silver-surfer/pkg/schemas"
+)
+
+var versionsCmd = &cobra.Command{
+	Use:   "versions",
+	Short: "List kubernetes versions whose openapi specs are bundled in the binary",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		versions := pkg.GetEmbeddedReleaseVersions()
+		if len(versions) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "no openapi specs are bundled in this binary, build with -tags offline to bundle them")
+			return
+		}
+		for _, version := range versions {
+			fmt.Fprintln(cmd.OutOrStdout(), version)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(versionsCmd)
+}
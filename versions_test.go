@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2021 Devtron Labs
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/devtron-labs/silver-surfer/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEmbeddedSpecs []string
+
+func (s testEmbeddedSpecs) ReleaseVersions() []string {
+	return append([]string{}, s...)
+}
+
+func (s testEmbeddedSpecs) Load(releaseVersion string) ([]byte, error) {
+	return nil, nil
+}
+
+func TestVersionsCmd(t *testing.T) {
+	tests := []struct {
+		name  string
+		specs pkg.EmbeddedSpecs
+		want  string
+	}{
+		{name: "no bundled specs", want: "no openapi specs are bundled in this binary, build with -tags offline to bundle them\n"},
+		{name: "bundled specs are sorted", specs: testEmbeddedSpecs{"1.29", "1.9", "1.24"}, want: "1.9\n1.24\n1.29\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg.RegisterEmbeddedSpecs(tt.specs)
+			defer pkg.RegisterEmbeddedSpecs(nil)
+			out := &bytes.Buffer{}
+			RootCmd.SetOut(out)
+			defer RootCmd.SetOut(nil)
+			RootCmd.SetArgs([]string{"versions"})
+			assert.NoError(t, RootCmd.Execute())
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}